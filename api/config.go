@@ -35,6 +35,7 @@ type Config struct {
 	GraphiteLocalMetricTTL    time.Duration
 	GraphiteRemoteMetricTTL   time.Duration
 	PrometheusRemoteMetricTTL time.Duration
+	RecycleBinTTL             time.Duration
 	Flags                     FeatureFlags
 }
 
@@ -1,6 +1,8 @@
 package controller
 
 import (
+	"time"
+
 	"github.com/moira-alert/moira"
 	"github.com/moira-alert/moira/api"
 	"github.com/moira-alert/moira/api/dto"
@@ -29,3 +31,38 @@ func UpdateNotifierState(database moira.Database, state *dto.NotifierState) *api
 	}
 	return nil
 }
+
+// GetSenderBlackouts returns active sender blackouts
+func GetSenderBlackouts(database moira.Database) (*dto.SenderBlackoutsList, *api.ErrorResponse) {
+	blackouts, err := database.GetSenderBlackouts()
+	if err != nil {
+		return nil, api.ErrorInternalServer(err)
+	}
+
+	now := time.Now().Unix()
+	list := &dto.SenderBlackoutsList{List: make([]moira.SenderBlackout, 0, len(blackouts))}
+	for _, blackout := range blackouts {
+		if blackout.IsActive(now) {
+			list.List = append(list.List, *blackout)
+		}
+	}
+	return list, nil
+}
+
+// SetSenderBlackout disables sender of given contact type for all users
+func SetSenderBlackout(database moira.Database, blackout *dto.SenderBlackout, userLogin string) *api.ErrorResponse {
+	blackout.CreatedBy = userLogin
+	blackout.CreatedAt = time.Now().Unix()
+	if err := database.SetSenderBlackout((*moira.SenderBlackout)(blackout)); err != nil {
+		return api.ErrorInternalServer(err)
+	}
+	return nil
+}
+
+// RemoveSenderBlackout enables sender of given contact type back
+func RemoveSenderBlackout(database moira.Database, contactType string) *api.ErrorResponse {
+	if err := database.RemoveSenderBlackout(contactType); err != nil {
+		return api.ErrorInternalServer(err)
+	}
+	return nil
+}
@@ -1,10 +1,13 @@
 package controller
 
 import (
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/api"
 	"github.com/moira-alert/moira/api/dto"
 	mock_moira_alert "github.com/moira-alert/moira/mock/moira-alert"
 	. "github.com/smartystreets/goconvey/convey"
@@ -58,3 +61,73 @@ func TestUpdateNotifierState(t *testing.T) {
 		So(err, ShouldBeNil)
 	})
 }
+
+func TestGetSenderBlackouts(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	dataBase := mock_moira_alert.NewMockDatabase(mockCtrl)
+	defer mockCtrl.Finish()
+
+	Convey("Should return only active blackouts", t, func() {
+		active := &moira.SenderBlackout{ContactType: "slack", Mode: moira.SenderBlackoutModeFallback}
+		finished := &moira.SenderBlackout{ContactType: "mail", Mode: moira.SenderBlackoutModeQueue, Until: time.Now().Add(-time.Minute).Unix()}
+		dataBase.EXPECT().GetSenderBlackouts().Return([]*moira.SenderBlackout{finished, active}, nil)
+
+		list, err := GetSenderBlackouts(dataBase)
+		So(err, ShouldBeNil)
+		So(list, ShouldResemble, &dto.SenderBlackoutsList{List: []moira.SenderBlackout{*active}})
+	})
+
+	Convey("GetSenderBlackouts error", t, func() {
+		expected := fmt.Errorf("getSenderBlackouts error")
+		dataBase.EXPECT().GetSenderBlackouts().Return(nil, expected)
+
+		list, err := GetSenderBlackouts(dataBase)
+		So(err, ShouldResemble, api.ErrorInternalServer(expected))
+		So(list, ShouldBeNil)
+	})
+}
+
+func TestSetSenderBlackout(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	dataBase := mock_moira_alert.NewMockDatabase(mockCtrl)
+	defer mockCtrl.Finish()
+
+	Convey("Should save blackout with author", t, func() {
+		blackout := &dto.SenderBlackout{ContactType: "slack", Mode: moira.SenderBlackoutModeQueue}
+		dataBase.EXPECT().SetSenderBlackout(gomock.Any()).Do(func(saved *moira.SenderBlackout) {
+			So(saved.ContactType, ShouldEqual, "slack")
+			So(saved.CreatedBy, ShouldEqual, "user")
+			So(saved.CreatedAt, ShouldBeGreaterThan, 0)
+		}).Return(nil)
+
+		err := SetSenderBlackout(dataBase, blackout, "user")
+		So(err, ShouldBeNil)
+	})
+
+	Convey("SetSenderBlackout error", t, func() {
+		expected := fmt.Errorf("setSenderBlackout error")
+		dataBase.EXPECT().SetSenderBlackout(gomock.Any()).Return(expected)
+
+		err := SetSenderBlackout(dataBase, &dto.SenderBlackout{ContactType: "slack"}, "user")
+		So(err, ShouldResemble, api.ErrorInternalServer(expected))
+	})
+}
+
+func TestRemoveSenderBlackout(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	dataBase := mock_moira_alert.NewMockDatabase(mockCtrl)
+	defer mockCtrl.Finish()
+
+	Convey("Should remove blackout", t, func() {
+		dataBase.EXPECT().RemoveSenderBlackout("slack").Return(nil)
+		err := RemoveSenderBlackout(dataBase, "slack")
+		So(err, ShouldBeNil)
+	})
+
+	Convey("RemoveSenderBlackout error", t, func() {
+		expected := fmt.Errorf("removeSenderBlackout error")
+		dataBase.EXPECT().RemoveSenderBlackout("slack").Return(expected)
+		err := RemoveSenderBlackout(dataBase, "slack")
+		So(err, ShouldResemble, api.ErrorInternalServer(expected))
+	})
+}
@@ -0,0 +1,140 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/api"
+	"github.com/moira-alert/moira/api/dto"
+	"github.com/moira-alert/moira/database"
+)
+
+// GetDeletedTriggers gets all triggers from recycle bin
+func GetDeletedTriggers(dataBase moira.Database) (*dto.DeletedTriggersList, *api.ErrorResponse) {
+	deletedTriggers, err := dataBase.GetRecycleBinTriggers()
+	if err != nil {
+		return nil, api.ErrorInternalServer(err)
+	}
+
+	deletedTriggersList := &dto.DeletedTriggersList{
+		List: make([]moira.DeletedTrigger, 0, len(deletedTriggers)),
+	}
+	for _, deletedTrigger := range deletedTriggers {
+		deletedTriggersList.List = append(deletedTriggersList.List, *deletedTrigger)
+	}
+
+	return deletedTriggersList, nil
+}
+
+// RestoreTrigger moves trigger from recycle bin back to the triggers
+func RestoreTrigger(dataBase moira.Database, triggerID string) (*dto.SaveTriggerResponse, *api.ErrorResponse) {
+	deletedTrigger, err := dataBase.GetRecycleBinTrigger(triggerID)
+	if err != nil {
+		if errors.Is(err, database.ErrNil) {
+			return nil, api.ErrorNotFound(fmt.Sprintf("trigger with ID = '%s' is not found in recycle bin", triggerID))
+		}
+		return nil, api.ErrorInternalServer(err)
+	}
+
+	exists, err := triggerExists(dataBase, triggerID)
+	if err != nil {
+		return nil, api.ErrorInternalServer(err)
+	}
+	if exists {
+		return nil, api.ErrorInvalidRequest(fmt.Errorf("trigger with this ID already exists"))
+	}
+
+	resp, errResponse := saveTrigger(dataBase, &deletedTrigger.Trigger, triggerID, make(map[string]bool))
+	if errResponse != nil {
+		return nil, errResponse
+	}
+
+	if err = dataBase.RemoveTriggerFromRecycleBin(triggerID); err != nil {
+		return nil, api.ErrorInternalServer(err)
+	}
+
+	resp.Message = "trigger restored"
+	return resp, nil
+}
+
+// GetUserDeletedSubscriptions gets subscriptions from recycle bin which belong to the user or to the user's teams
+func GetUserDeletedSubscriptions(dataBase moira.Database, userLogin string) (*dto.DeletedSubscriptionsList, *api.ErrorResponse) {
+	deletedSubscriptions, err := dataBase.GetRecycleBinSubscriptions()
+	if err != nil {
+		return nil, api.ErrorInternalServer(err)
+	}
+
+	userTeams, err := dataBase.GetUserTeams(userLogin)
+	if err != nil {
+		return nil, api.ErrorInternalServer(err)
+	}
+	teams := make(map[string]struct{}, len(userTeams))
+	for _, teamID := range userTeams {
+		teams[teamID] = struct{}{}
+	}
+
+	deletedSubscriptionsList := &dto.DeletedSubscriptionsList{
+		List: make([]moira.DeletedSubscription, 0),
+	}
+	for _, deletedSubscription := range deletedSubscriptions {
+		subscription := deletedSubscription.Subscription
+		if subscription.TeamID != "" {
+			if _, ok := teams[subscription.TeamID]; !ok {
+				continue
+			}
+		} else if subscription.User != userLogin {
+			continue
+		}
+		deletedSubscriptionsList.List = append(deletedSubscriptionsList.List, *deletedSubscription)
+	}
+
+	return deletedSubscriptionsList, nil
+}
+
+// RestoreSubscription moves subscription from recycle bin back to the subscriptions of the user or team it belonged to
+func RestoreSubscription(dataBase moira.Database, subscriptionID string, userLogin string) (*dto.Subscription, *api.ErrorResponse) {
+	deletedSubscription, err := dataBase.GetRecycleBinSubscription(subscriptionID)
+	if err != nil {
+		if errors.Is(err, database.ErrNil) {
+			return nil, api.ErrorNotFound(fmt.Sprintf("subscription with ID '%s' is not found in recycle bin", subscriptionID))
+		}
+		return nil, api.ErrorInternalServer(err)
+	}
+
+	subscription := deletedSubscription.Subscription
+	if subscription.TeamID != "" {
+		teamContainsUser, err := dataBase.IsTeamContainUser(subscription.TeamID, userLogin)
+		if err != nil {
+			return nil, api.ErrorInternalServer(err)
+		}
+		if !teamContainsUser {
+			return nil, api.ErrorForbidden("you are not permitted")
+		}
+	} else if subscription.User != userLogin {
+		return nil, api.ErrorForbidden("you are not permitted")
+	}
+
+	exists, err := isSubscriptionExists(dataBase, subscriptionID)
+	if err != nil {
+		return nil, api.ErrorInternalServer(err)
+	}
+	if exists {
+		return nil, api.ErrorInvalidRequest(fmt.Errorf("subscription with this ID already exists"))
+	}
+
+	if err = dto.CheckSubscriptionContacts(dataBase, &subscription, userLogin, subscription.TeamID); err != nil {
+		return nil, api.ErrorInvalidRequest(err)
+	}
+
+	if err = dataBase.SaveSubscription(&subscription); err != nil {
+		return nil, api.ErrorInternalServer(err)
+	}
+
+	if err = dataBase.RemoveSubscriptionFromRecycleBin(subscriptionID); err != nil {
+		return nil, api.ErrorInternalServer(err)
+	}
+
+	restored := dto.Subscription(subscription)
+	return &restored, nil
+}
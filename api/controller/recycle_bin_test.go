@@ -0,0 +1,212 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/api"
+	"github.com/moira-alert/moira/api/dto"
+	"github.com/moira-alert/moira/database"
+	mock_moira_alert "github.com/moira-alert/moira/mock/moira-alert"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestGetDeletedTriggers(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	dataBase := mock_moira_alert.NewMockDatabase(mockCtrl)
+
+	Convey("Has deleted triggers", t, func() {
+		deletedTriggers := []*moira.DeletedTrigger{
+			{Trigger: moira.Trigger{ID: "trigger1"}, DeletedBy: "user", DeletedAt: 1},
+			{Trigger: moira.Trigger{ID: "trigger2"}, DeletedBy: "user", DeletedAt: 2},
+		}
+		dataBase.EXPECT().GetRecycleBinTriggers().Return(deletedTriggers, nil)
+		list, err := GetDeletedTriggers(dataBase)
+		So(err, ShouldBeNil)
+		So(list, ShouldResemble, &dto.DeletedTriggersList{List: []moira.DeletedTrigger{*deletedTriggers[0], *deletedTriggers[1]}})
+	})
+
+	Convey("GetRecycleBinTriggers error", t, func() {
+		expected := fmt.Errorf("getRecycleBinTriggers error")
+		dataBase.EXPECT().GetRecycleBinTriggers().Return(nil, expected)
+		list, err := GetDeletedTriggers(dataBase)
+		So(err, ShouldResemble, api.ErrorInternalServer(expected))
+		So(list, ShouldBeNil)
+	})
+}
+
+func TestRestoreTrigger(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	dataBase := mock_moira_alert.NewMockDatabase(mockCtrl)
+	triggerID := "trigger1"
+	deletedTrigger := moira.DeletedTrigger{Trigger: moira.Trigger{ID: triggerID}, DeletedBy: "user", DeletedAt: 1}
+
+	Convey("Success", t, func() {
+		dataBase.EXPECT().GetRecycleBinTrigger(triggerID).Return(deletedTrigger, nil)
+		dataBase.EXPECT().GetTrigger(triggerID).Return(moira.Trigger{}, database.ErrNil)
+		dataBase.EXPECT().AcquireTriggerCheckLock(triggerID, maxTriggerLockAttempts).Return(nil)
+		dataBase.EXPECT().DeleteTriggerCheckLock(triggerID).Return(nil)
+		dataBase.EXPECT().GetTriggerLastCheck(triggerID).Return(moira.CheckData{}, database.ErrNil)
+		dataBase.EXPECT().SetTriggerLastCheck(triggerID, gomock.Any(), gomock.Any()).Return(nil)
+		dataBase.EXPECT().SaveTrigger(triggerID, &deletedTrigger.Trigger).Return(nil)
+		dataBase.EXPECT().RemoveTriggerFromRecycleBin(triggerID).Return(nil)
+		resp, err := RestoreTrigger(dataBase, triggerID)
+		So(err, ShouldBeNil)
+		So(resp, ShouldResemble, &dto.SaveTriggerResponse{ID: triggerID, Message: "trigger restored"})
+	})
+
+	Convey("Trigger is not in recycle bin", t, func() {
+		dataBase.EXPECT().GetRecycleBinTrigger(triggerID).Return(moira.DeletedTrigger{}, database.ErrNil)
+		resp, err := RestoreTrigger(dataBase, triggerID)
+		So(err, ShouldResemble, api.ErrorNotFound(fmt.Sprintf("trigger with ID = '%s' is not found in recycle bin", triggerID)))
+		So(resp, ShouldBeNil)
+	})
+
+	Convey("Trigger with the same ID exists", t, func() {
+		dataBase.EXPECT().GetRecycleBinTrigger(triggerID).Return(deletedTrigger, nil)
+		dataBase.EXPECT().GetTrigger(triggerID).Return(moira.Trigger{ID: triggerID}, nil)
+		resp, err := RestoreTrigger(dataBase, triggerID)
+		So(err, ShouldResemble, api.ErrorInvalidRequest(fmt.Errorf("trigger with this ID already exists")))
+		So(resp, ShouldBeNil)
+	})
+
+	Convey("RemoveTriggerFromRecycleBin error", t, func() {
+		expected := fmt.Errorf("removeTriggerFromRecycleBin error")
+		dataBase.EXPECT().GetRecycleBinTrigger(triggerID).Return(deletedTrigger, nil)
+		dataBase.EXPECT().GetTrigger(triggerID).Return(moira.Trigger{}, database.ErrNil)
+		dataBase.EXPECT().AcquireTriggerCheckLock(triggerID, maxTriggerLockAttempts).Return(nil)
+		dataBase.EXPECT().DeleteTriggerCheckLock(triggerID).Return(nil)
+		dataBase.EXPECT().GetTriggerLastCheck(triggerID).Return(moira.CheckData{}, database.ErrNil)
+		dataBase.EXPECT().SetTriggerLastCheck(triggerID, gomock.Any(), gomock.Any()).Return(nil)
+		dataBase.EXPECT().SaveTrigger(triggerID, &deletedTrigger.Trigger).Return(nil)
+		dataBase.EXPECT().RemoveTriggerFromRecycleBin(triggerID).Return(expected)
+		resp, err := RestoreTrigger(dataBase, triggerID)
+		So(err, ShouldResemble, api.ErrorInternalServer(expected))
+		So(resp, ShouldBeNil)
+	})
+}
+
+func TestGetUserDeletedSubscriptions(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	dataBase := mock_moira_alert.NewMockDatabase(mockCtrl)
+	login := "user"
+
+	Convey("Returns only user and user teams subscriptions", t, func() {
+		userSubscription := &moira.DeletedSubscription{Subscription: moira.SubscriptionData{ID: "sub1", User: login}}
+		teamSubscription := &moira.DeletedSubscription{Subscription: moira.SubscriptionData{ID: "sub2", TeamID: "team1"}}
+		otherUserSubscription := &moira.DeletedSubscription{Subscription: moira.SubscriptionData{ID: "sub3", User: "other"}}
+		otherTeamSubscription := &moira.DeletedSubscription{Subscription: moira.SubscriptionData{ID: "sub4", TeamID: "team2"}}
+		dataBase.EXPECT().GetRecycleBinSubscriptions().Return([]*moira.DeletedSubscription{
+			userSubscription, teamSubscription, otherUserSubscription, otherTeamSubscription,
+		}, nil)
+		dataBase.EXPECT().GetUserTeams(login).Return([]string{"team1"}, nil)
+		list, err := GetUserDeletedSubscriptions(dataBase, login)
+		So(err, ShouldBeNil)
+		So(list, ShouldResemble, &dto.DeletedSubscriptionsList{List: []moira.DeletedSubscription{*userSubscription, *teamSubscription}})
+	})
+
+	Convey("GetRecycleBinSubscriptions error", t, func() {
+		expected := fmt.Errorf("getRecycleBinSubscriptions error")
+		dataBase.EXPECT().GetRecycleBinSubscriptions().Return(nil, expected)
+		list, err := GetUserDeletedSubscriptions(dataBase, login)
+		So(err, ShouldResemble, api.ErrorInternalServer(expected))
+		So(list, ShouldBeNil)
+	})
+}
+
+func TestRestoreSubscription(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	dataBase := mock_moira_alert.NewMockDatabase(mockCtrl)
+	login := "user"
+	subscriptionID := "sub1"
+
+	Convey("Success", t, func() {
+		subscription := moira.SubscriptionData{ID: subscriptionID, User: login, Contacts: []string{"contact1"}}
+		dataBase.EXPECT().GetRecycleBinSubscription(subscriptionID).Return(moira.DeletedSubscription{Subscription: subscription}, nil)
+		dataBase.EXPECT().GetSubscription(subscriptionID).Return(moira.SubscriptionData{}, database.ErrNil)
+		dataBase.EXPECT().GetUserContactIDs(login).Return([]string{"contact1", "contact2"}, nil)
+		dataBase.EXPECT().SaveSubscription(&subscription).Return(nil)
+		dataBase.EXPECT().RemoveSubscriptionFromRecycleBin(subscriptionID).Return(nil)
+		restored, err := RestoreSubscription(dataBase, subscriptionID, login)
+		So(err, ShouldBeNil)
+		So(*restored, ShouldResemble, dto.Subscription(subscription))
+	})
+
+	Convey("Success with team subscription", t, func() {
+		subscription := moira.SubscriptionData{ID: subscriptionID, TeamID: "team1", Contacts: []string{"contact1"}}
+		dataBase.EXPECT().GetRecycleBinSubscription(subscriptionID).Return(moira.DeletedSubscription{Subscription: subscription}, nil)
+		dataBase.EXPECT().IsTeamContainUser("team1", login).Return(true, nil)
+		dataBase.EXPECT().GetSubscription(subscriptionID).Return(moira.SubscriptionData{}, database.ErrNil)
+		dataBase.EXPECT().GetTeamContactIDs("team1").Return([]string{"contact1"}, nil)
+		dataBase.EXPECT().SaveSubscription(&subscription).Return(nil)
+		dataBase.EXPECT().RemoveSubscriptionFromRecycleBin(subscriptionID).Return(nil)
+		restored, err := RestoreSubscription(dataBase, subscriptionID, login)
+		So(err, ShouldBeNil)
+		So(*restored, ShouldResemble, dto.Subscription(subscription))
+	})
+
+	Convey("Subscription contact has been deleted", t, func() {
+		subscription := moira.SubscriptionData{ID: subscriptionID, User: login, Contacts: []string{"contact1", "deleted"}}
+		dataBase.EXPECT().GetRecycleBinSubscription(subscriptionID).Return(moira.DeletedSubscription{Subscription: subscription}, nil)
+		dataBase.EXPECT().GetSubscription(subscriptionID).Return(moira.SubscriptionData{}, database.ErrNil)
+		dataBase.EXPECT().GetUserContactIDs(login).Return([]string{"contact1"}, nil)
+		dataBase.EXPECT().GetContacts([]string{"deleted"}).Return([]*moira.ContactData{nil}, nil)
+		restored, err := RestoreSubscription(dataBase, subscriptionID, login)
+		So(err.HTTPStatusCode, ShouldEqual, http.StatusBadRequest)
+		So(err.ErrorText, ShouldEqual, "failed to identify the ownership of the contact id 'deleted'")
+		So(restored, ShouldBeNil)
+	})
+
+	Convey("Subscription contact belongs to another user now", t, func() {
+		subscription := moira.SubscriptionData{ID: subscriptionID, TeamID: "team1", Contacts: []string{"contact1"}}
+		dataBase.EXPECT().GetRecycleBinSubscription(subscriptionID).Return(moira.DeletedSubscription{Subscription: subscription}, nil)
+		dataBase.EXPECT().IsTeamContainUser("team1", login).Return(true, nil)
+		dataBase.EXPECT().GetSubscription(subscriptionID).Return(moira.SubscriptionData{}, database.ErrNil)
+		dataBase.EXPECT().GetTeamContactIDs("team1").Return([]string{}, nil)
+		dataBase.EXPECT().GetContacts([]string{"contact1"}).Return([]*moira.ContactData{{ID: "contact1", Value: "mail@example.com"}}, nil)
+		restored, err := RestoreSubscription(dataBase, subscriptionID, login)
+		So(err.HTTPStatusCode, ShouldEqual, http.StatusBadRequest)
+		So(err.ErrorText, ShouldEqual, "user not permitted to use contact 'mail@example.com'")
+		So(restored, ShouldBeNil)
+	})
+
+	Convey("Subscription is not in recycle bin", t, func() {
+		dataBase.EXPECT().GetRecycleBinSubscription(subscriptionID).Return(moira.DeletedSubscription{}, database.ErrNil)
+		restored, err := RestoreSubscription(dataBase, subscriptionID, login)
+		So(err, ShouldResemble, api.ErrorNotFound(fmt.Sprintf("subscription with ID '%s' is not found in recycle bin", subscriptionID)))
+		So(restored, ShouldBeNil)
+	})
+
+	Convey("Subscription of another user", t, func() {
+		subscription := moira.SubscriptionData{ID: subscriptionID, User: "other"}
+		dataBase.EXPECT().GetRecycleBinSubscription(subscriptionID).Return(moira.DeletedSubscription{Subscription: subscription}, nil)
+		restored, err := RestoreSubscription(dataBase, subscriptionID, login)
+		So(err, ShouldResemble, api.ErrorForbidden("you are not permitted"))
+		So(restored, ShouldBeNil)
+	})
+
+	Convey("Subscription of another team", t, func() {
+		subscription := moira.SubscriptionData{ID: subscriptionID, TeamID: "team2"}
+		dataBase.EXPECT().GetRecycleBinSubscription(subscriptionID).Return(moira.DeletedSubscription{Subscription: subscription}, nil)
+		dataBase.EXPECT().IsTeamContainUser("team2", login).Return(false, nil)
+		restored, err := RestoreSubscription(dataBase, subscriptionID, login)
+		So(err, ShouldResemble, api.ErrorForbidden("you are not permitted"))
+		So(restored, ShouldBeNil)
+	})
+
+	Convey("Subscription with the same ID exists", t, func() {
+		subscription := moira.SubscriptionData{ID: subscriptionID, User: login}
+		dataBase.EXPECT().GetRecycleBinSubscription(subscriptionID).Return(moira.DeletedSubscription{Subscription: subscription}, nil)
+		dataBase.EXPECT().GetSubscription(subscriptionID).Return(subscription, nil)
+		restored, err := RestoreSubscription(dataBase, subscriptionID, login)
+		So(err, ShouldResemble, api.ErrorInvalidRequest(fmt.Errorf("subscription with this ID already exists")))
+		So(restored, ShouldBeNil)
+	})
+}
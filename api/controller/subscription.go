@@ -78,9 +78,33 @@ func UpdateSubscription(dataBase moira.Database, subscriptionID string, userLogi
 	return nil
 }
 
-// RemoveSubscription deletes subscription
-func RemoveSubscription(database moira.Database, subscriptionID string) *api.ErrorResponse {
-	if err := database.RemoveSubscription(subscriptionID); err != nil {
+// RemoveSubscription deletes subscription, if recycleBinTTL is positive the subscription is kept in recycle bin for this time
+func RemoveSubscription(dataBase moira.Database, subscriptionID string, userLogin string, recycleBinTTL time.Duration) *api.ErrorResponse {
+	movedToRecycleBin := false
+	if recycleBinTTL > 0 {
+		subscription, err := dataBase.GetSubscription(subscriptionID)
+		if err != nil && !errors.Is(err, database.ErrNil) {
+			return api.ErrorInternalServer(err)
+		}
+		if err == nil {
+			deletedSubscription := &moira.DeletedSubscription{
+				Subscription: subscription,
+				DeletedBy:    userLogin,
+				DeletedAt:    time.Now().Unix(),
+			}
+			if err = dataBase.AddSubscriptionToRecycleBin(deletedSubscription, recycleBinTTL); err != nil {
+				return api.ErrorInternalServer(err)
+			}
+			movedToRecycleBin = true
+		}
+	}
+
+	if err := dataBase.RemoveSubscription(subscriptionID); err != nil {
+		if movedToRecycleBin {
+			if rollbackErr := dataBase.RemoveSubscriptionFromRecycleBin(subscriptionID); rollbackErr != nil {
+				return api.ErrorInternalServer(fmt.Errorf("%s, failed to remove subscription from recycle bin: %s", err.Error(), rollbackErr.Error()))
+			}
+		}
 		return api.ErrorInternalServer(err)
 	}
 	return nil
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/gofrs/uuid"
 	"github.com/golang/mock/gomock"
@@ -135,19 +136,67 @@ func TestRemoveSubscription(t *testing.T) {
 	defer mockCtrl.Finish()
 	db := mock_moira_alert.NewMockDatabase(mockCtrl)
 	id := uuid.Must(uuid.NewV4()).String()
+	login := "user"
 
 	Convey("Success", t, func() {
 		db.EXPECT().RemoveSubscription(id).Return(nil)
-		err := RemoveSubscription(db, id)
+		err := RemoveSubscription(db, id, login, 0)
 		So(err, ShouldBeNil)
 	})
 
 	Convey("Error", t, func() {
 		expected := fmt.Errorf("oooops! Can not remove subscription")
 		db.EXPECT().RemoveSubscription(id).Return(expected)
-		err := RemoveSubscription(db, id)
+		err := RemoveSubscription(db, id, login, 0)
 		So(err, ShouldResemble, api.ErrorInternalServer(expected))
 	})
+
+	Convey("Success with recycle bin", t, func() {
+		subscription := moira.SubscriptionData{ID: id, User: login}
+		db.EXPECT().GetSubscription(id).Return(subscription, nil)
+		db.EXPECT().AddSubscriptionToRecycleBin(gomock.Any(), time.Hour).DoAndReturn(func(deleted *moira.DeletedSubscription, ttl time.Duration) error {
+			So(deleted.Subscription, ShouldResemble, subscription)
+			So(deleted.DeletedBy, ShouldEqual, login)
+			return nil
+		})
+		db.EXPECT().RemoveSubscription(id).Return(nil)
+		err := RemoveSubscription(db, id, login, time.Hour)
+		So(err, ShouldBeNil)
+	})
+
+	Convey("Success with recycle bin and no subscription", t, func() {
+		db.EXPECT().GetSubscription(id).Return(moira.SubscriptionData{}, database.ErrNil)
+		db.EXPECT().RemoveSubscription(id).Return(nil)
+		err := RemoveSubscription(db, id, login, time.Hour)
+		So(err, ShouldBeNil)
+	})
+
+	Convey("Error add to recycle bin", t, func() {
+		expected := fmt.Errorf("oooops! Can not add subscription to recycle bin")
+		db.EXPECT().GetSubscription(id).Return(moira.SubscriptionData{ID: id}, nil)
+		db.EXPECT().AddSubscriptionToRecycleBin(gomock.Any(), time.Hour).Return(expected)
+		err := RemoveSubscription(db, id, login, time.Hour)
+		So(err, ShouldResemble, api.ErrorInternalServer(expected))
+	})
+
+	Convey("Error remove subscription after adding it to recycle bin", t, func() {
+		expected := fmt.Errorf("oooops! Can not remove subscription")
+		db.EXPECT().GetSubscription(id).Return(moira.SubscriptionData{ID: id}, nil)
+		db.EXPECT().AddSubscriptionToRecycleBin(gomock.Any(), time.Hour).Return(nil)
+		db.EXPECT().RemoveSubscription(id).Return(expected)
+		db.EXPECT().RemoveSubscriptionFromRecycleBin(id).Return(nil)
+		err := RemoveSubscription(db, id, login, time.Hour)
+		So(err, ShouldResemble, api.ErrorInternalServer(expected))
+	})
+
+	Convey("Error rollback adding to recycle bin", t, func() {
+		db.EXPECT().GetSubscription(id).Return(moira.SubscriptionData{ID: id}, nil)
+		db.EXPECT().AddSubscriptionToRecycleBin(gomock.Any(), time.Hour).Return(nil)
+		db.EXPECT().RemoveSubscription(id).Return(fmt.Errorf("remove error"))
+		db.EXPECT().RemoveSubscriptionFromRecycleBin(id).Return(fmt.Errorf("rollback error"))
+		err := RemoveSubscription(db, id, login, time.Hour)
+		So(err, ShouldResemble, api.ErrorInternalServer(fmt.Errorf("remove error, failed to remove subscription from recycle bin: rollback error")))
+	})
 }
 
 func TestSendTestNotification(t *testing.T) {
@@ -95,9 +95,33 @@ func GetTrigger(dataBase moira.Database, triggerID string) (*dto.Trigger, *api.E
 	return &triggerResponse, nil
 }
 
-// RemoveTrigger deletes trigger by given triggerID
-func RemoveTrigger(database moira.Database, triggerID string) *api.ErrorResponse {
-	if err := database.RemoveTrigger(triggerID); err != nil {
+// RemoveTrigger deletes trigger by given triggerID, if recycleBinTTL is positive the trigger is kept in recycle bin for this time
+func RemoveTrigger(dataBase moira.Database, triggerID string, userLogin string, recycleBinTTL time.Duration) *api.ErrorResponse {
+	movedToRecycleBin := false
+	if recycleBinTTL > 0 {
+		trigger, err := dataBase.GetTrigger(triggerID)
+		if err != nil && !errors.Is(err, database.ErrNil) {
+			return api.ErrorInternalServer(err)
+		}
+		if err == nil {
+			deletedTrigger := &moira.DeletedTrigger{
+				Trigger:   trigger,
+				DeletedBy: userLogin,
+				DeletedAt: time.Now().Unix(),
+			}
+			if err = dataBase.AddTriggerToRecycleBin(deletedTrigger, recycleBinTTL); err != nil {
+				return api.ErrorInternalServer(err)
+			}
+			movedToRecycleBin = true
+		}
+	}
+
+	if err := dataBase.RemoveTrigger(triggerID); err != nil {
+		if movedToRecycleBin {
+			if rollbackErr := dataBase.RemoveTriggerFromRecycleBin(triggerID); rollbackErr != nil {
+				return api.ErrorInternalServer(fmt.Errorf("%s, failed to remove trigger from recycle bin: %s", err.Error(), rollbackErr.Error()))
+			}
+		}
 		return api.ErrorInternalServer(err)
 	}
 	return nil
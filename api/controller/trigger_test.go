@@ -304,21 +304,75 @@ func TestRemoveTrigger(t *testing.T) {
 
 	Convey("Success", t, func() {
 		dataBase.EXPECT().RemoveTrigger(triggerID).Return(nil)
-		err := RemoveTrigger(dataBase, triggerID)
+		err := RemoveTrigger(dataBase, triggerID, "user", 0)
 		So(err, ShouldBeNil)
 	})
 
 	Convey("Error remove trigger", t, func() {
 		expected := fmt.Errorf("oooops! Error delete")
 		dataBase.EXPECT().RemoveTrigger(triggerID).Return(expected)
-		err := RemoveTrigger(dataBase, triggerID)
+		err := RemoveTrigger(dataBase, triggerID, "user", 0)
 		So(err, ShouldResemble, api.ErrorInternalServer(expected))
 	})
 
 	Convey("Error remove last check", t, func() {
 		expected := fmt.Errorf("oooops! Error delete")
 		dataBase.EXPECT().RemoveTrigger(triggerID).Return(expected)
-		err := RemoveTrigger(dataBase, triggerID)
+		err := RemoveTrigger(dataBase, triggerID, "user", 0)
+		So(err, ShouldResemble, api.ErrorInternalServer(expected))
+	})
+
+	Convey("Success with recycle bin", t, func() {
+		trigger := moira.Trigger{ID: triggerID}
+		dataBase.EXPECT().GetTrigger(triggerID).Return(trigger, nil)
+		dataBase.EXPECT().AddTriggerToRecycleBin(gomock.Any(), time.Hour).DoAndReturn(func(deleted *moira.DeletedTrigger, ttl time.Duration) error {
+			So(deleted.Trigger, ShouldResemble, trigger)
+			So(deleted.DeletedBy, ShouldEqual, "user")
+			return nil
+		})
+		dataBase.EXPECT().RemoveTrigger(triggerID).Return(nil)
+		err := RemoveTrigger(dataBase, triggerID, "user", time.Hour)
+		So(err, ShouldBeNil)
+	})
+
+	Convey("Success with recycle bin and no trigger", t, func() {
+		dataBase.EXPECT().GetTrigger(triggerID).Return(moira.Trigger{}, database.ErrNil)
+		dataBase.EXPECT().RemoveTrigger(triggerID).Return(nil)
+		err := RemoveTrigger(dataBase, triggerID, "user", time.Hour)
+		So(err, ShouldBeNil)
+	})
+
+	Convey("Error add to recycle bin", t, func() {
+		expected := fmt.Errorf("oooops! Error add to recycle bin")
+		dataBase.EXPECT().GetTrigger(triggerID).Return(moira.Trigger{ID: triggerID}, nil)
+		dataBase.EXPECT().AddTriggerToRecycleBin(gomock.Any(), time.Hour).Return(expected)
+		err := RemoveTrigger(dataBase, triggerID, "user", time.Hour)
+		So(err, ShouldResemble, api.ErrorInternalServer(expected))
+	})
+
+	Convey("Error remove trigger after adding it to recycle bin", t, func() {
+		expected := fmt.Errorf("oooops! Error remove trigger")
+		dataBase.EXPECT().GetTrigger(triggerID).Return(moira.Trigger{ID: triggerID}, nil)
+		dataBase.EXPECT().AddTriggerToRecycleBin(gomock.Any(), time.Hour).Return(nil)
+		dataBase.EXPECT().RemoveTrigger(triggerID).Return(expected)
+		dataBase.EXPECT().RemoveTriggerFromRecycleBin(triggerID).Return(nil)
+		err := RemoveTrigger(dataBase, triggerID, "user", time.Hour)
+		So(err, ShouldResemble, api.ErrorInternalServer(expected))
+	})
+
+	Convey("Error rollback adding to recycle bin", t, func() {
+		dataBase.EXPECT().GetTrigger(triggerID).Return(moira.Trigger{ID: triggerID}, nil)
+		dataBase.EXPECT().AddTriggerToRecycleBin(gomock.Any(), time.Hour).Return(nil)
+		dataBase.EXPECT().RemoveTrigger(triggerID).Return(fmt.Errorf("remove error"))
+		dataBase.EXPECT().RemoveTriggerFromRecycleBin(triggerID).Return(fmt.Errorf("rollback error"))
+		err := RemoveTrigger(dataBase, triggerID, "user", time.Hour)
+		So(err, ShouldResemble, api.ErrorInternalServer(fmt.Errorf("remove error, failed to remove trigger from recycle bin: rollback error")))
+	})
+
+	Convey("Error remove trigger without recycle bin", t, func() {
+		expected := fmt.Errorf("oooops! Error remove trigger")
+		dataBase.EXPECT().RemoveTrigger(triggerID).Return(expected)
+		err := RemoveTrigger(dataBase, triggerID, "user", 0)
 		So(err, ShouldResemble, api.ErrorInternalServer(expected))
 	})
 }
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"math"
 	"regexp"
+	"strconv"
 
 	"github.com/gofrs/uuid"
 
@@ -187,6 +188,79 @@ func GetUnusedTriggerIDs(database moira.Database) (*dto.TriggersList, *api.Error
 	return triggersList, nil
 }
 
+// GetTriggersStateChanges returns triggers whose state was changed after the given checkpoint
+func GetTriggersStateChanges(database moira.Database, checkpoint string) (*dto.TriggersStateChanges, *api.ErrorResponse) {
+	var from int64
+	if checkpoint != "" {
+		var err error
+		from, err = strconv.ParseInt(checkpoint, 10, 64)
+		if err != nil || from < 0 {
+			return nil, api.ErrorInvalidRequest(fmt.Errorf("invalid checkpoint: %s", checkpoint))
+		}
+	}
+
+	changes, err := database.GetTriggersStateChanges(from)
+	if err != nil {
+		return nil, api.ErrorInternalServer(err)
+	}
+
+	stateChanges := &dto.TriggersStateChanges{
+		List: make([]moira.TriggerStateChange, 0, len(changes)),
+	}
+	for _, change := range changes {
+		if change.Sequence > from {
+			from = change.Sequence
+		}
+		stateChanges.List = append(stateChanges.List, *change)
+	}
+	stateChanges.Checkpoint = strconv.FormatInt(from, 10)
+
+	return stateChanges, nil
+}
+
+// GetTriggersLastCheckSummaries gets selected fields of last checks for given triggers or for triggers with given tag
+func GetTriggersLastCheckSummaries(database moira.Database, triggerIDs []string, tag string, fields []string) (*dto.TriggersLastCheckSummaries, *api.ErrorResponse) {
+	if tag != "" && len(triggerIDs) > 0 {
+		return nil, api.ErrorInvalidRequest(fmt.Errorf("cannot handle request with both trigger IDs and tag set"))
+	}
+	if tag == "" && len(triggerIDs) == 0 {
+		return nil, api.ErrorInvalidRequest(fmt.Errorf("trigger IDs or tag must be set"))
+	}
+
+	if len(fields) == 0 {
+		fields = dto.LastCheckSummaryDefaultFields
+	}
+	if err := dto.CheckLastCheckSummaryFields(fields); err != nil {
+		return nil, api.ErrorInvalidRequest(err)
+	}
+
+	if tag != "" {
+		var err error
+		triggerIDs, err = database.GetTagTriggerIDs(tag)
+		if err != nil {
+			return nil, api.ErrorInternalServer(err)
+		}
+	}
+
+	lastChecks, err := database.GetTriggersLastCheck(triggerIDs)
+	if err != nil {
+		return nil, api.ErrorInternalServer(err)
+	}
+
+	summaries := &dto.TriggersLastCheckSummaries{
+		List: make([]dto.TriggerLastCheckSummary, 0, len(triggerIDs)),
+	}
+	for i, triggerID := range triggerIDs {
+		lastCheck := lastChecks[i]
+		if lastCheck != nil {
+			lastCheck.RemoveDeadMetrics()
+		}
+		summaries.List = append(summaries.List, dto.NewTriggerLastCheckSummary(triggerID, lastCheck, fields))
+	}
+
+	return summaries, nil
+}
+
 func getTriggerChecks(database moira.Database, triggerIDs []string) ([]moira.TriggerCheck, error) {
 	triggerChecks, err := database.GetTriggerChecks(triggerIDs)
 	if err != nil {
@@ -1191,3 +1191,129 @@ func TestGetUnusedTriggerIDs(t *testing.T) {
 		So(actual, ShouldResemble, expected)
 	})
 }
+
+func TestGetTriggersStateChanges(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockDatabase := mock_moira_alert.NewMockDatabase(mockCtrl)
+
+	Convey("Has changes", t, func() {
+		changes := []*moira.TriggerStateChange{
+			{TriggerID: "trigger1", State: moira.StateERROR, Timestamp: 1590741878, Sequence: 12},
+			{TriggerID: "trigger2", State: moira.StateOK, Timestamp: 1590741818, Sequence: 15},
+			{TriggerID: "trigger3", Deleted: true, Timestamp: 1590741938, Sequence: 14},
+		}
+		mockDatabase.EXPECT().GetTriggersStateChanges(int64(10)).Return(changes, nil)
+		list, err := GetTriggersStateChanges(mockDatabase, "10")
+		So(err, ShouldBeNil)
+		So(list, ShouldResemble, &dto.TriggersStateChanges{
+			Checkpoint: "15",
+			List:       []moira.TriggerStateChange{*changes[0], *changes[1], *changes[2]},
+		})
+		So(list.List[0].Timestamp, ShouldEqual, 1590741878)
+		So(list.List[2].Deleted, ShouldBeTrue)
+		So(list.List[2].Timestamp, ShouldEqual, 1590741938)
+	})
+
+	Convey("No changes since checkpoint", t, func() {
+		mockDatabase.EXPECT().GetTriggersStateChanges(int64(10)).Return(make([]*moira.TriggerStateChange, 0), nil)
+		list, err := GetTriggersStateChanges(mockDatabase, "10")
+		So(err, ShouldBeNil)
+		So(list, ShouldResemble, &dto.TriggersStateChanges{
+			Checkpoint: "10",
+			List:       make([]moira.TriggerStateChange, 0),
+		})
+	})
+
+	Convey("Empty checkpoint", t, func() {
+		mockDatabase.EXPECT().GetTriggersStateChanges(int64(0)).Return(make([]*moira.TriggerStateChange, 0), nil)
+		list, err := GetTriggersStateChanges(mockDatabase, "")
+		So(err, ShouldBeNil)
+		So(list.Checkpoint, ShouldEqual, "0")
+	})
+
+	Convey("Invalid checkpoint", t, func() {
+		list, err := GetTriggersStateChanges(mockDatabase, "abc")
+		So(err, ShouldResemble, api.ErrorInvalidRequest(fmt.Errorf("invalid checkpoint: abc")))
+		So(list, ShouldBeNil)
+	})
+
+	Convey("GetTriggersStateChanges error", t, func() {
+		expected := fmt.Errorf("getTriggersStateChanges error")
+		mockDatabase.EXPECT().GetTriggersStateChanges(int64(0)).Return(nil, expected)
+		list, err := GetTriggersStateChanges(mockDatabase, "")
+		So(err, ShouldResemble, api.ErrorInternalServer(expected))
+		So(list, ShouldBeNil)
+	})
+}
+
+func TestGetTriggersLastCheckSummaries(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockDatabase := mock_moira_alert.NewMockDatabase(mockCtrl)
+
+	lastCheck := &moira.CheckData{
+		Score:     100,
+		State:     moira.StateERROR,
+		Timestamp: 1590741878,
+		Metrics: map[string]moira.MetricState{
+			"metric": {State: moira.StateERROR, Timestamp: 1590741878},
+		},
+	}
+
+	Convey("By trigger IDs with selected fields", t, func() {
+		mockDatabase.EXPECT().GetTriggersLastCheck([]string{"trigger1", "trigger2"}).Return([]*moira.CheckData{lastCheck, nil}, nil)
+		summaries, err := GetTriggersLastCheckSummaries(mockDatabase, []string{"trigger1", "trigger2"}, "", []string{"state", "score"})
+		So(err, ShouldBeNil)
+		So(summaries, ShouldResemble, &dto.TriggersLastCheckSummaries{
+			List: []dto.TriggerLastCheckSummary{
+				{TriggerID: "trigger1", LastCheck: map[string]interface{}{"state": moira.StateERROR, "score": int64(100)}},
+				{TriggerID: "trigger2"},
+			},
+		})
+	})
+
+	Convey("By tag with default fields", t, func() {
+		mockDatabase.EXPECT().GetTagTriggerIDs("tag").Return([]string{"trigger1"}, nil)
+		mockDatabase.EXPECT().GetTriggersLastCheck([]string{"trigger1"}).Return([]*moira.CheckData{lastCheck}, nil)
+		summaries, err := GetTriggersLastCheckSummaries(mockDatabase, nil, "tag", nil)
+		So(err, ShouldBeNil)
+		So(summaries.List, ShouldHaveLength, 1)
+		So(summaries.List[0].LastCheck, ShouldHaveLength, len(dto.LastCheckSummaryDefaultFields))
+		So(summaries.List[0].LastCheck, ShouldNotContainKey, "metrics")
+	})
+
+	Convey("Both trigger IDs and tag set", t, func() {
+		summaries, err := GetTriggersLastCheckSummaries(mockDatabase, []string{"trigger1"}, "tag", nil)
+		So(err, ShouldResemble, api.ErrorInvalidRequest(fmt.Errorf("cannot handle request with both trigger IDs and tag set")))
+		So(summaries, ShouldBeNil)
+	})
+
+	Convey("Neither trigger IDs nor tag set", t, func() {
+		summaries, err := GetTriggersLastCheckSummaries(mockDatabase, nil, "", nil)
+		So(err, ShouldResemble, api.ErrorInvalidRequest(fmt.Errorf("trigger IDs or tag must be set")))
+		So(summaries, ShouldBeNil)
+	})
+
+	Convey("Unknown field", t, func() {
+		summaries, err := GetTriggersLastCheckSummaries(mockDatabase, []string{"trigger1"}, "", []string{"state", "unknown"})
+		So(err, ShouldResemble, api.ErrorInvalidRequest(fmt.Errorf("unknown last check field: unknown")))
+		So(summaries, ShouldBeNil)
+	})
+
+	Convey("GetTagTriggerIDs error", t, func() {
+		expected := fmt.Errorf("getTagTriggerIDs error")
+		mockDatabase.EXPECT().GetTagTriggerIDs("tag").Return(nil, expected)
+		summaries, err := GetTriggersLastCheckSummaries(mockDatabase, nil, "tag", nil)
+		So(err, ShouldResemble, api.ErrorInternalServer(expected))
+		So(summaries, ShouldBeNil)
+	})
+
+	Convey("GetTriggersLastCheck error", t, func() {
+		expected := fmt.Errorf("getTriggersLastCheck error")
+		mockDatabase.EXPECT().GetTriggersLastCheck([]string{"trigger1"}).Return(nil, expected)
+		summaries, err := GetTriggersLastCheckSummaries(mockDatabase, []string{"trigger1"}, "", nil)
+		So(err, ShouldResemble, api.ErrorInternalServer(expected))
+		So(summaries, ShouldBeNil)
+	})
+}
@@ -4,8 +4,11 @@ package dto
 import (
 	"fmt"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/api/middleware"
 )
 
 const (
@@ -30,3 +33,37 @@ func (state *NotifierState) Bind(r *http.Request) error {
 	}
 	return nil
 }
+
+type SenderBlackout moira.SenderBlackout
+
+func (*SenderBlackout) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+func (blackout *SenderBlackout) Bind(r *http.Request) error {
+	if blackout.ContactType == "" {
+		return fmt.Errorf("contact_type can not be empty")
+	}
+	contactTypes := middleware.GetContactTypes(r)
+	if !moira.Subset([]string{blackout.ContactType}, contactTypes) {
+		return fmt.Errorf("unknown contact_type '%s'. Contact type should be one of: <%s>", blackout.ContactType, strings.Join(contactTypes, "|"))
+	}
+	if blackout.Mode == "" {
+		blackout.Mode = moira.SenderBlackoutModeFallback
+	}
+	if blackout.Mode != moira.SenderBlackoutModeFallback && blackout.Mode != moira.SenderBlackoutModeQueue {
+		return fmt.Errorf("invalid mode '%s'. Mode should be one of: <%s|%s>", blackout.Mode, moira.SenderBlackoutModeFallback, moira.SenderBlackoutModeQueue)
+	}
+	if blackout.Until != 0 && blackout.Until <= time.Now().Unix() {
+		return fmt.Errorf("until should be in the future")
+	}
+	return nil
+}
+
+type SenderBlackoutsList struct {
+	List []moira.SenderBlackout `json:"list"`
+}
+
+func (*SenderBlackoutsList) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
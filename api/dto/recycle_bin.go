@@ -0,0 +1,24 @@
+// nolint
+package dto
+
+import (
+	"net/http"
+
+	"github.com/moira-alert/moira"
+)
+
+type DeletedTriggersList struct {
+	List []moira.DeletedTrigger `json:"list"`
+}
+
+func (*DeletedTriggersList) Render(http.ResponseWriter, *http.Request) error {
+	return nil
+}
+
+type DeletedSubscriptionsList struct {
+	List []moira.DeletedSubscription `json:"list"`
+}
+
+func (*DeletedSubscriptionsList) Render(http.ResponseWriter, *http.Request) error {
+	return nil
+}
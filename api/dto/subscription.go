@@ -77,6 +77,11 @@ func (subscription *Subscription) checkContacts(request *http.Request) error {
 	if subscription.User != "" && teamID != "" {
 		return ErrSubscriptionContainsTeamAndUser{}
 	}
+	return CheckSubscriptionContacts(database, (*moira.SubscriptionData)(subscription), userLogin, teamID)
+}
+
+// CheckSubscriptionContacts checks that all subscription contacts exist and belong to the given team or user if team is empty
+func CheckSubscriptionContacts(database moira.Database, subscription *moira.SubscriptionData, userLogin, teamID string) error {
 	var contactIDs []string
 	var err error
 	if teamID != "" {
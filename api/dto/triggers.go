@@ -456,3 +456,97 @@ type TriggersSearchResultDeleteResponse struct {
 func (TriggersSearchResultDeleteResponse) Render(http.ResponseWriter, *http.Request) error {
 	return nil
 }
+
+type TriggersStateChanges struct {
+	// Token to pass as checkpoint in the next request to get only newer changes
+	Checkpoint string                     `json:"checkpoint" example:"42"`
+	List       []moira.TriggerStateChange `json:"list"`
+}
+
+func (*TriggersStateChanges) Render(http.ResponseWriter, *http.Request) error {
+	return nil
+}
+
+// TriggerLastCheckSummary is a representation of trigger last check with only selected fields
+type TriggerLastCheckSummary struct {
+	TriggerID string                 `json:"trigger_id" example:"292516ed-4924-4154-a62c-ebe312431fce"`
+	LastCheck map[string]interface{} `json:"last_check" extensions:"x-nullable"`
+}
+
+type TriggersLastCheckSummaries struct {
+	List []TriggerLastCheckSummary `json:"list"`
+}
+
+func (*TriggersLastCheckSummaries) Render(http.ResponseWriter, *http.Request) error {
+	return nil
+}
+
+// LastCheckSummaryDefaultFields are last check fields returned if no fields are selected, metrics are omitted as the heaviest ones
+var LastCheckSummaryDefaultFields = []string{
+	"score",
+	"state",
+	"maintenance",
+	"maintenance_info",
+	"timestamp",
+	"event_timestamp",
+	"last_successful_check_timestamp",
+	"suppressed",
+	"suppressed_state",
+	"msg",
+}
+
+// CheckLastCheckSummaryFields returns error if there is unknown last check field among given ones
+func CheckLastCheckSummaryFields(fields []string) error {
+	for _, field := range fields {
+		if _, err := getLastCheckField(&moira.CheckData{}, field); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NewTriggerLastCheckSummary creates last check summary containing only given fields of check data,
+// fields must be checked with CheckLastCheckSummaryFields before
+func NewTriggerLastCheckSummary(triggerID string, checkData *moira.CheckData, fields []string) TriggerLastCheckSummary {
+	summary := TriggerLastCheckSummary{TriggerID: triggerID}
+	if checkData == nil {
+		return summary
+	}
+
+	summary.LastCheck = make(map[string]interface{}, len(fields))
+	for _, field := range fields {
+		summary.LastCheck[field], _ = getLastCheckField(checkData, field)
+	}
+	return summary
+}
+
+func getLastCheckField(checkData *moira.CheckData, field string) (interface{}, error) {
+	switch field {
+	case "metrics":
+		return checkData.Metrics, nil
+	case "metrics_to_target_relation":
+		return checkData.MetricsToTargetRelation, nil
+	case "score":
+		return checkData.Score, nil
+	case "state":
+		return checkData.State, nil
+	case "maintenance":
+		return checkData.Maintenance, nil
+	case "maintenance_info":
+		return checkData.MaintenanceInfo, nil
+	case "timestamp":
+		return checkData.Timestamp, nil
+	case "event_timestamp":
+		return checkData.EventTimestamp, nil
+	case "last_successful_check_timestamp":
+		return checkData.LastSuccessfulCheckTimestamp, nil
+	case "suppressed":
+		return checkData.Suppressed, nil
+	case "suppressed_state":
+		return checkData.SuppressedState, nil
+	case "msg":
+		return checkData.Message, nil
+	default:
+		return nil, fmt.Errorf("unknown last check field: %s", field)
+	}
+}
@@ -24,3 +24,11 @@ func getWebConfig(webConfig *api.WebConfig) http.HandlerFunc {
 		}
 	})
 }
+
+func getContactTypes(webConfig *api.WebConfig) []string {
+	contactTypes := make([]string, 0, len(webConfig.Contacts))
+	for _, contact := range webConfig.Contacts {
+		contactTypes = append(contactTypes, contact.ContactType)
+	}
+	return contactTypes
+}
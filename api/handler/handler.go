@@ -92,9 +92,10 @@ func NewHandler(
 	//	@tag.description	APIs for interacting with Moira users
 	router.Route("/api", func(router chi.Router) {
 		router.Use(moiramiddle.DatabaseContext(database))
-		router.Route("/health", health)
+		router.With(moiramiddle.ContactTypes(getContactTypes(webConfig))).Route("/health", health)
 		router.Route("/", func(router chi.Router) {
 			router.Use(moiramiddle.ReadOnlyMiddleware(apiConfig))
+			router.Use(moiramiddle.RecycleBin(apiConfig.RecycleBinTTL))
 			router.Get("/config", getWebConfig(webConfig))
 			router.Route("/user", user)
 			router.With(moiramiddle.Triggers(
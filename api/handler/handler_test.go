@@ -30,7 +30,9 @@ func TestReadonlyMode(t *testing.T) {
 		config := &api.Config{Flags: api.FeatureFlags{IsReadonlyEnabled: true}}
 		webConfig := &api.WebConfig{
 			SupportEmail: "test",
-			Contacts:     []api.WebContact{},
+			Contacts: []api.WebContact{
+				{ContactType: "slack", ContactLabel: "Slack"},
+			},
 		}
 		handler := NewHandler(mockDb, logger, nil, config, nil, webConfig)
 
@@ -75,6 +77,42 @@ func TestReadonlyMode(t *testing.T) {
 			So(response.StatusCode, ShouldEqual, http.StatusOK)
 		})
 
+		Convey("Put sender blackout", func() {
+			mockDb.EXPECT().SetSenderBlackout(gomock.Any()).Return(nil).Times(1)
+
+			blackout := &dto.SenderBlackout{
+				ContactType: "slack",
+			}
+
+			blackoutBytes, err := json.Marshal(blackout)
+			So(err, ShouldBeNil)
+
+			testRequest := httptest.NewRequest(http.MethodPut, "/api/health/sender-blackouts", bytes.NewReader(blackoutBytes))
+
+			handler.ServeHTTP(responseWriter, testRequest)
+
+			response := responseWriter.Result()
+			defer response.Body.Close()
+			So(response.StatusCode, ShouldEqual, http.StatusOK)
+		})
+
+		Convey("Put sender blackout with unknown contact type", func() {
+			blackout := &dto.SenderBlackout{
+				ContactType: "unknown",
+			}
+
+			blackoutBytes, err := json.Marshal(blackout)
+			So(err, ShouldBeNil)
+
+			testRequest := httptest.NewRequest(http.MethodPut, "/api/health/sender-blackouts", bytes.NewReader(blackoutBytes))
+
+			handler.ServeHTTP(responseWriter, testRequest)
+
+			response := responseWriter.Result()
+			defer response.Body.Close()
+			So(response.StatusCode, ShouldEqual, http.StatusBadRequest)
+		})
+
 		Convey("Put new trigger", func() {
 			trigger := &dto.Trigger{}
 			triggerBytes, err := json.Marshal(trigger)
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"fmt"
 	"net/http"
 
 	"github.com/go-chi/chi"
@@ -8,11 +9,17 @@ import (
 	"github.com/moira-alert/moira/api"
 	"github.com/moira-alert/moira/api/controller"
 	"github.com/moira-alert/moira/api/dto"
+	"github.com/moira-alert/moira/api/middleware"
 )
 
 func health(router chi.Router) {
 	router.Get("/notifier", getNotifierState)
 	router.Put("/notifier", setNotifierState)
+	router.Route("/sender-blackouts", func(router chi.Router) {
+		router.Get("/", getSenderBlackouts)
+		router.Put("/", setSenderBlackout)
+		router.Delete("/{contactType}", removeSenderBlackout)
+	})
 }
 
 // nolint: gofmt,goimports
@@ -55,3 +62,83 @@ func setNotifierState(writer http.ResponseWriter, request *http.Request) {
 		return
 	}
 }
+
+// nolint: gofmt,goimports
+//
+//	@summary	Get senders disabled for all users
+//	@id			get-sender-blackouts
+//	@tags		health
+//	@produce	json
+//	@success	200	{object}	dto.SenderBlackoutsList			"Active sender blackouts retrieved"
+//	@failure	422	{object}	api.ErrorRenderExample			"Render error"
+//	@failure	500	{object}	api.ErrorInternalServerExample	"Internal server error"
+//	@router		/health/sender-blackouts [get]
+func getSenderBlackouts(writer http.ResponseWriter, request *http.Request) {
+	blackouts, err := controller.GetSenderBlackouts(database)
+	if err != nil {
+		render.Render(writer, request, err) //nolint
+		return
+	}
+
+	if err := render.Render(writer, request, blackouts); err != nil {
+		render.Render(writer, request, api.ErrorRender(err)) //nolint
+		return
+	}
+}
+
+// nolint: gofmt,goimports
+//
+//	@summary		Disable sender of given contact type for all users
+//	@description	In fallback mode notifications to disabled sender are skipped if subscription has other contacts, otherwise they are postponed until blackout ends
+//	@description	In queue mode notifications to disabled sender are always postponed until blackout ends
+//	@id				set-sender-blackout
+//	@tags			health
+//	@accept			json
+//	@produce		json
+//	@param			blackout	body		dto.SenderBlackout				true	"Sender blackout to set"
+//	@success		200			{object}	dto.SenderBlackout				"Sender blackout set"
+//	@failure		400			{object}	api.ErrorInvalidRequestExample	"Bad request from client"
+//	@failure		422			{object}	api.ErrorRenderExample			"Render error"
+//	@failure		500			{object}	api.ErrorInternalServerExample	"Internal server error"
+//	@router			/health/sender-blackouts [put]
+func setSenderBlackout(writer http.ResponseWriter, request *http.Request) {
+	blackout := &dto.SenderBlackout{}
+	if err := render.Bind(request, blackout); err != nil {
+		render.Render(writer, request, api.ErrorInvalidRequest(err)) //nolint
+		return
+	}
+
+	userLogin := middleware.GetLogin(request)
+	if err := controller.SetSenderBlackout(database, blackout, userLogin); err != nil {
+		render.Render(writer, request, err) //nolint
+		return
+	}
+
+	if err := render.Render(writer, request, blackout); err != nil {
+		render.Render(writer, request, api.ErrorRender(err)) //nolint
+		return
+	}
+}
+
+// nolint: gofmt,goimports
+//
+//	@summary	Enable sender of given contact type back
+//	@id			remove-sender-blackout
+//	@tags		health
+//	@produce	json
+//	@param		contactType	path	string	true	"Contact type of disabled sender"	default(slack)
+//	@success	200			"Sender blackout removed"
+//	@failure	400			{object}	api.ErrorInvalidRequestExample	"Bad request from client"
+//	@failure	500			{object}	api.ErrorInternalServerExample	"Internal server error"
+//	@router		/health/sender-blackouts/{contactType} [delete]
+func removeSenderBlackout(writer http.ResponseWriter, request *http.Request) {
+	contactType := chi.URLParam(request, "contactType")
+	if contactType == "" {
+		render.Render(writer, request, api.ErrorInvalidRequest(fmt.Errorf("contact type must be set"))) //nolint
+		return
+	}
+
+	if err := controller.RemoveSenderBlackout(database, contactType); err != nil {
+		render.Render(writer, request, err) //nolint
+	}
+}
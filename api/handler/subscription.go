@@ -17,6 +17,10 @@ import (
 func subscription(router chi.Router) {
 	router.Get("/", getUserSubscriptions)
 	router.Put("/", createSubscription)
+	router.Route("/deleted", func(router chi.Router) {
+		router.Get("/", getUserDeletedSubscriptions)
+		router.With(middleware.SubscriptionContext).Put("/{subscriptionId}/restore", restoreSubscription)
+	})
 	router.Route("/{subscriptionId}", func(router chi.Router) {
 		router.Use(middleware.SubscriptionContext)
 		router.Use(subscriptionFilter)
@@ -86,6 +90,57 @@ func createSubscription(writer http.ResponseWriter, request *http.Request) {
 	}
 }
 
+// nolint: gofmt,goimports
+//
+//	@summary	Get user subscriptions from the recycle bin
+//	@id			get-user-deleted-subscriptions
+//	@tags		subscription
+//	@produce	json
+//	@success	200	{object}	dto.DeletedSubscriptionsList	"Deleted subscriptions fetched successfully"
+//	@failure	422	{object}	api.ErrorRenderExample			"Render error"
+//	@failure	500	{object}	api.ErrorInternalServerExample	"Internal server error"
+//	@router		/subscription/deleted [get]
+func getUserDeletedSubscriptions(writer http.ResponseWriter, request *http.Request) {
+	userLogin := middleware.GetLogin(request)
+	deletedSubscriptions, err := controller.GetUserDeletedSubscriptions(database, userLogin)
+	if err != nil {
+		render.Render(writer, request, err) //nolint
+		return
+	}
+	if err := render.Render(writer, request, deletedSubscriptions); err != nil {
+		render.Render(writer, request, api.ErrorRender(err)) //nolint
+		return
+	}
+}
+
+// nolint: gofmt,goimports
+//
+//	@summary	Restore subscription from the recycle bin
+//	@id			restore-subscription
+//	@tags		subscription
+//	@produce	json
+//	@param		subscriptionID	path		string							true	"ID of the subscription to restore"	default(bcba82f5-48cf-44c0-b7d6-e1d32c64a88c)
+//	@success	200				{object}	dto.Subscription				"Subscription restored successfully"
+//	@failure	400				{object}	api.ErrorInvalidRequestExample	"Bad request from client"
+//	@failure	403				{object}	api.ErrorForbiddenExample		"Forbidden"
+//	@failure	404				{object}	api.ErrorNotFoundExample		"Resource not found"
+//	@failure	422				{object}	api.ErrorRenderExample			"Render error"
+//	@failure	500				{object}	api.ErrorInternalServerExample	"Internal server error"
+//	@router		/subscription/deleted/{subscriptionID}/restore [put]
+func restoreSubscription(writer http.ResponseWriter, request *http.Request) {
+	subscriptionID := middleware.GetSubscriptionID(request)
+	userLogin := middleware.GetLogin(request)
+	subscription, err := controller.RestoreSubscription(database, subscriptionID, userLogin)
+	if err != nil {
+		render.Render(writer, request, err) //nolint
+		return
+	}
+	if err := render.Render(writer, request, subscription); err != nil {
+		render.Render(writer, request, api.ErrorRender(err)) //nolint
+		return
+	}
+}
+
 // subscriptionFilter is middleware for check subscription existence and user permissions
 func subscriptionFilter(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
@@ -150,19 +205,22 @@ func updateSubscription(writer http.ResponseWriter, request *http.Request) {
 
 // nolint: gofmt,goimports
 //
-//	@summary	Delete a subscription
-//	@id			remove-subscription
-//	@tags		subscription
-//	@produce	json
-//	@param		subscriptionID	path	string	true	"ID of the subscription to remove"	default(bcba82f5-48cf-44c0-b7d6-e1d32c64a88c)
-//	@success	200				"Subscription deleted"
-//	@failure	403				{object}	api.ErrorForbiddenExample		"Forbidden"
-//	@failure	404				{object}	api.ErrorNotFoundExample		"Resource not found"
-//	@failure	500				{object}	api.ErrorInternalServerExample	"Internal server error"
-//	@router		/subscription/{subscriptionID} [delete]
+//	@summary		Delete a subscription
+//	@description	Subscription is moved to the recycle bin unless hard deletion is requested
+//	@id				remove-subscription
+//	@tags			subscription
+//	@produce		json
+//	@param			subscriptionID	path	string	true	"ID of the subscription to remove"				default(bcba82f5-48cf-44c0-b7d6-e1d32c64a88c)
+//	@param			hard			query	bool	false	"Delete permanently, bypassing the recycle bin"	default(false)
+//	@success		200				"Subscription deleted"
+//	@failure		403				{object}	api.ErrorForbiddenExample		"Forbidden"
+//	@failure		404				{object}	api.ErrorNotFoundExample		"Resource not found"
+//	@failure		500				{object}	api.ErrorInternalServerExample	"Internal server error"
+//	@router			/subscription/{subscriptionID} [delete]
 func removeSubscription(writer http.ResponseWriter, request *http.Request) {
 	subscriptionID := middleware.GetSubscriptionID(request)
-	if err := controller.RemoveSubscription(database, subscriptionID); err != nil {
+	userLogin := middleware.GetLogin(request)
+	if err := controller.RemoveSubscription(database, subscriptionID, userLogin, getRecycleBinTTL(request)); err != nil {
 		render.Render(writer, request, err) //nolint
 	}
 }
@@ -123,17 +123,20 @@ func writeErrorSaveResponse(writer http.ResponseWriter, request *http.Request, t
 
 // nolint: gofmt,goimports
 //
-//	@summary	Remove trigger
-//	@id			remove-trigger
-//	@tags		trigger
-//	@param		triggerID	path	string	true	"Trigger ID"	default(bcba82f5-48cf-44c0-b7d6-e1d32c64a88c)
-//	@success	200			"Successfully removed"
-//	@failure	404			{object}	api.ErrorNotFoundExample		"Resource not found"
-//	@failure	500			{object}	api.ErrorInternalServerExample	"Internal server error"
-//	@router		/trigger/{triggerID} [delete]
+//	@summary		Remove trigger
+//	@description	Trigger is moved to the recycle bin unless hard deletion is requested
+//	@id				remove-trigger
+//	@tags			trigger
+//	@param			triggerID	path	string	true	"Trigger ID"									default(bcba82f5-48cf-44c0-b7d6-e1d32c64a88c)
+//	@param			hard		query	bool	false	"Delete permanently, bypassing the recycle bin"	default(false)
+//	@success		200			"Successfully removed"
+//	@failure		404			{object}	api.ErrorNotFoundExample		"Resource not found"
+//	@failure		500			{object}	api.ErrorInternalServerExample	"Internal server error"
+//	@router			/trigger/{triggerID} [delete]
 func removeTrigger(writer http.ResponseWriter, request *http.Request) {
 	triggerID := middleware.GetTriggerID(request)
-	err := controller.RemoveTrigger(database, triggerID)
+	userLogin := middleware.GetLogin(request)
+	err := controller.RemoveTrigger(database, triggerID, userLogin, getRecycleBinTTL(request))
 	if err != nil {
 		render.Render(writer, request, err) //nolint
 	}
@@ -29,6 +29,12 @@ func triggers(metricSourceProvider *metricSource.SourceProvider, searcher moira.
 		router.Use(middleware.SearchIndexContext(searcher))
 		router.Get("/", getAllTriggers)
 		router.Get("/unused", getUnusedTriggers)
+		router.Get("/state-changes", getTriggersStateChanges)
+		router.Get("/last-check", getTriggersLastCheck)
+		router.Route("/deleted", func(router chi.Router) {
+			router.Get("/", getDeletedTriggers)
+			router.With(middleware.TriggerContext).Put("/{triggerId}/restore", restoreTrigger)
+		})
 		router.Put("/", createTrigger)
 		router.Put("/check", triggerCheck)
 		router.Route("/{triggerId}", trigger)
@@ -85,6 +91,119 @@ func getUnusedTriggers(writer http.ResponseWriter, request *http.Request) {
 	}
 }
 
+// nolint: gofmt,goimports
+//
+//	@summary		Get triggers whose state was changed since the checkpoint
+//	@description	Use the checkpoint from the response in the next request to fetch only newer changes
+//	@description	Deleted triggers are returned as changes with deleted flag set and without state
+//	@id				get-triggers-state-changes
+//	@tags			trigger
+//	@produce		json
+//	@param			checkpoint	query		string							false	"Checkpoint from the previous response"	default(42)
+//	@success		200			{object}	dto.TriggersStateChanges		"Fetched triggers state changes"
+//	@failure		400			{object}	api.ErrorInvalidRequestExample	"Bad request from client"
+//	@failure		422			{object}	api.ErrorRenderExample			"Render error"
+//	@failure		500			{object}	api.ErrorInternalServerExample	"Internal server error"
+//	@router			/trigger/state-changes [get]
+func getTriggersStateChanges(writer http.ResponseWriter, request *http.Request) {
+	checkpoint := request.URL.Query().Get("checkpoint")
+
+	stateChanges, errorResponse := controller.GetTriggersStateChanges(database, checkpoint)
+	if errorResponse != nil {
+		render.Render(writer, request, errorResponse) //nolint
+		return
+	}
+
+	if err := render.Render(writer, request, stateChanges); err != nil {
+		render.Render(writer, request, api.ErrorRender(err)) //nolint
+		return
+	}
+}
+
+// nolint: gofmt,goimports
+//
+//	@summary		Get last check summaries of several triggers
+//	@description	Define triggers by IDs using query parameters triggerIDs[0]=id1, triggerIDs[1]=id2 and so on, or by tag
+//	@description	Use query parameters fields[0]=state, fields[1]=score and so on to select last check fields, all fields except metrics are returned by default
+//	@id				get-triggers-last-check
+//	@tags			trigger
+//	@produce		json
+//	@param			tag	query		string							false	"Tag of triggers"	default(cpu)
+//	@success		200	{object}	dto.TriggersLastCheckSummaries	"Fetched triggers last check summaries"
+//	@failure		400	{object}	api.ErrorInvalidRequestExample	"Bad request from client"
+//	@failure		422	{object}	api.ErrorRenderExample			"Render error"
+//	@failure		500	{object}	api.ErrorInternalServerExample	"Internal server error"
+//	@router			/trigger/last-check [get]
+func getTriggersLastCheck(writer http.ResponseWriter, request *http.Request) {
+	request.ParseForm() //nolint
+
+	triggerIDs := getRequestArray(request, "triggerIDs")
+	tag := request.FormValue("tag")
+	fields := getRequestArray(request, "fields")
+
+	summaries, errorResponse := controller.GetTriggersLastCheckSummaries(database, triggerIDs, tag, fields)
+	if errorResponse != nil {
+		render.Render(writer, request, errorResponse) //nolint
+		return
+	}
+
+	if err := render.Render(writer, request, summaries); err != nil {
+		render.Render(writer, request, api.ErrorRender(err)) //nolint
+		return
+	}
+}
+
+// nolint: gofmt,goimports
+//
+//	@summary	Get triggers from the recycle bin
+//	@id			get-deleted-triggers
+//	@tags		trigger
+//	@produce	json
+//	@success	200	{object}	dto.DeletedTriggersList			"Fetched deleted triggers"
+//	@failure	422	{object}	api.ErrorRenderExample			"Render error"
+//	@failure	500	{object}	api.ErrorInternalServerExample	"Internal server error"
+//	@router		/trigger/deleted [get]
+func getDeletedTriggers(writer http.ResponseWriter, request *http.Request) {
+	deletedTriggers, errorResponse := controller.GetDeletedTriggers(database)
+	if errorResponse != nil {
+		render.Render(writer, request, errorResponse) //nolint
+		return
+	}
+
+	if err := render.Render(writer, request, deletedTriggers); err != nil {
+		render.Render(writer, request, api.ErrorRender(err)) //nolint
+		return
+	}
+}
+
+// nolint: gofmt,goimports
+//
+//	@summary	Restore trigger from the recycle bin
+//	@id			restore-trigger
+//	@tags		trigger
+//	@produce	json
+//	@param		triggerID	path		string							true	"Trigger ID"	default(bcba82f5-48cf-44c0-b7d6-e1d32c64a88c)
+//	@success	200			{object}	dto.SaveTriggerResponse			"Trigger restored successfully"
+//	@failure	400			{object}	api.ErrorInvalidRequestExample	"Bad request from client"
+//	@failure	404			{object}	api.ErrorNotFoundExample		"Resource not found"
+//	@failure	422			{object}	api.ErrorRenderExample			"Render error"
+//	@failure	500			{object}	api.ErrorInternalServerExample	"Internal server error"
+//	@router		/trigger/deleted/{triggerID}/restore [put]
+func restoreTrigger(writer http.ResponseWriter, request *http.Request) {
+	triggerID := middleware.GetTriggerID(request)
+
+	response, errorResponse := controller.RestoreTrigger(database, triggerID)
+	if errorResponse != nil {
+		render.Render(writer, request, errorResponse) //nolint
+		return
+	}
+
+	if err := render.Render(writer, request, response); err != nil {
+		render.Render(writer, request, api.ErrorRender(err)) //nolint
+		return
+	}
+}
+
 // nolint: gofmt,goimports
 // createTrigger handler creates moira.Trigger
 //
@@ -313,17 +432,22 @@ func deletePager(writer http.ResponseWriter, request *http.Request) {
 }
 
 func getRequestTags(request *http.Request) []string {
-	var filterTags []string
+	return getRequestArray(request, "tags")
+}
+
+// getRequestArray gets values of query parameters like name[0]=value1, name[1]=value2 and so on
+func getRequestArray(request *http.Request, name string) []string {
+	var values []string
 	i := 0
 	for {
-		tag := request.FormValue(fmt.Sprintf("tags[%v]", i))
-		if tag == "" {
+		value := request.FormValue(fmt.Sprintf("%s[%v]", name, i))
+		if value == "" {
 			break
 		}
-		filterTags = append(filterTags, tag)
+		values = append(values, value)
 		i++
 	}
-	return filterTags
+	return values
 }
 
 func getOnlyProblemsFlag(request *http.Request) bool {
@@ -335,6 +459,14 @@ func getOnlyProblemsFlag(request *http.Request) bool {
 	return false
 }
 
+// getRecycleBinTTL returns the time to keep deleted object in recycle bin, or zero if hard deletion is requested
+func getRecycleBinTTL(request *http.Request) time.Duration {
+	if hard, _ := strconv.ParseBool(request.URL.Query().Get("hard")); hard {
+		return 0
+	}
+	return middleware.GetRecycleBinTTL(request)
+}
+
 // Checks if the createdBy field has been set:
 // if the field has been set, searches for triggers with a specific author createdBy
 // if the field has not been set, searches for triggers with any author
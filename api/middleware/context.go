@@ -196,6 +196,26 @@ func Triggers(localMetricTTL, remoteMetricTTL, prometheusMetricTTL time.Duration
 	}
 }
 
+// RecycleBin sets to request context the duration time to keep deleted triggers and subscriptions in recycle bin
+func RecycleBin(recycleBinTTL time.Duration) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			ctx := context.WithValue(request.Context(), recycleBinTTLKey, recycleBinTTL)
+			next.ServeHTTP(writer, request.WithContext(ctx))
+		})
+	}
+}
+
+// ContactTypes sets to request context the contact types configured for web ui
+func ContactTypes(contactTypes []string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			ctx := context.WithValue(request.Context(), contactTypesKey, contactTypes)
+			next.ServeHTTP(writer, request.WithContext(ctx))
+		})
+	}
+}
+
 // DateRange gets from and to values from URI query and set it to request context. If query has not values sets given values
 func DateRange(defaultFrom, defaultTo string) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
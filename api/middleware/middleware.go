@@ -39,6 +39,8 @@ var (
 	targetNameKey          ContextKey = "target"
 	teamIDKey              ContextKey = "teamID"
 	teamUserIDKey          ContextKey = "teamUserIDKey"
+	recycleBinTTLKey       ContextKey = "recycleBinTTL"
+	contactTypesKey        ContextKey = "contactTypes"
 	anonymousUser                     = "anonymous"
 )
 
@@ -68,11 +70,21 @@ func GetLocalMetricTTL(request *http.Request) time.Duration {
 	return request.Context().Value(localMetricTTLKey).(time.Duration)
 }
 
+// GetContactTypes gets configured contact types from request context, which was sets in ContactTypes middleware
+func GetContactTypes(request *http.Request) []string {
+	return request.Context().Value(contactTypesKey).([]string)
+}
+
 // GetRemoteMetricTTL gets remote metric ttl duration time from request context, which was sets in TriggerContext middleware
 func GetRemoteMetricTTL(request *http.Request) time.Duration {
 	return request.Context().Value(remoteMetricTTLKey).(time.Duration)
 }
 
+// GetRecycleBinTTL gets duration time to keep deleted objects in recycle bin from request context, which was sets in RecycleBin middleware
+func GetRecycleBinTTL(request *http.Request) time.Duration {
+	return request.Context().Value(recycleBinTTLKey).(time.Duration)
+}
+
 // GetRemoteMetricTTL gets remote metric ttl duration time from request context, which was sets in TriggerContext middleware
 func GetPrometheusMetricTTL(request *http.Request) time.Duration {
 	return request.Context().Value(prometheusMetricTTLKey).(time.Duration)
@@ -25,6 +25,8 @@ type apiConfig struct {
 	Listen string `yaml:"listen"`
 	// If true, CORS for cross-domain requests will be enabled. This option can be used only for debugging purposes.
 	EnableCORS bool `yaml:"enable_cors"`
+	// Time to keep deleted triggers and subscriptions in the recycle bin. If empty or zero, objects are deleted permanently.
+	RecycleBinTTL string `yaml:"recycle_bin_ttl"`
 }
 
 type sentryConfig struct {
@@ -82,6 +84,7 @@ func (config *apiConfig) getSettings(
 		Listen:                  config.Listen,
 		GraphiteLocalMetricTTL:  to.Duration(localMetricTTL),
 		GraphiteRemoteMetricTTL: to.Duration(remoteMetricTTL),
+		RecycleBinTTL:           to.Duration(config.RecycleBinTTL),
 		Flags:                   flags,
 	}
 }
@@ -135,8 +138,9 @@ func getDefault() config {
 			LogPrettyFormat: false,
 		},
 		API: apiConfig{
-			Listen:     ":8081",
-			EnableCORS: false,
+			Listen:        ":8081",
+			EnableCORS:    false,
+			RecycleBinTTL: "168h",
 		},
 		Web: webConfig{
 			RemoteAllowed: false,
@@ -14,8 +14,9 @@ import (
 func Test_apiConfig_getSettings(t *testing.T) {
 	Convey("Settings successfully filled", t, func() {
 		apiConf := apiConfig{
-			Listen:     "0000",
-			EnableCORS: true,
+			Listen:        "0000",
+			EnableCORS:    true,
+			RecycleBinTTL: "168h",
 		}
 
 		expectedResult := &api.Config{
@@ -23,6 +24,7 @@ func Test_apiConfig_getSettings(t *testing.T) {
 			Listen:                  "0000",
 			GraphiteLocalMetricTTL:  time.Hour,
 			GraphiteRemoteMetricTTL: 24 * time.Hour,
+			RecycleBinTTL:           168 * time.Hour,
 			Flags:                   api.FeatureFlags{IsReadonlyEnabled: true},
 		}
 
@@ -67,8 +69,9 @@ func Test_webConfig_getDefault(t *testing.T) {
 				LogPrettyFormat: false,
 			},
 			API: apiConfig{
-				Listen:     ":8081",
-				EnableCORS: false,
+				Listen:        ":8081",
+				EnableCORS:    false,
+				RecycleBinTTL: "168h",
 			},
 			Web: webConfig{
 				RemoteAllowed: false,
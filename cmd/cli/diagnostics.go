@@ -0,0 +1,456 @@
+package main
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	rds "github.com/go-redis/redis/v8"
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/database/redis"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	redactedValue = "<redacted>"
+	// logTailSize is the size of log file ending that is searched for recent errors
+	logTailSize = 10 * 1024 * 1024
+	// recentErrorsLimit is the max count of recent errors taken from every log file
+	recentErrorsLimit = 200
+)
+
+// secretKeyParts are parts of config keys, which values must not get into diagnostics bundle
+var secretKeyParts = []string{"pass", "secret", "token", "api_key", "apikey", "access_key", "private_key", "credentials", "dsn"}
+
+// redisInfoSections are sections of Redis INFO command, which get into diagnostics bundle
+var redisInfoSections = []string{"server", "clients", "memory", "persistence", "stats", "replication", "keyspace"}
+
+type diagnosticsFile struct {
+	name    string
+	content []byte
+}
+
+// diagnosticsBundle collects files of diagnostics archive, failed collection steps do not stop collection
+// and are saved to the archive too
+type diagnosticsBundle struct {
+	files  []diagnosticsFile
+	errors []string
+}
+
+type diagnosticsMeta struct {
+	MoiraVersion string `json:"moira_version"`
+	GitCommit    string `json:"git_commit"`
+	GoVersion    string `json:"go_version"`
+	CreatedAt    string `json:"created_at"`
+}
+
+type diagnosticsQueues struct {
+	LocalTriggersToCheck      int64 `json:"local_triggers_to_check"`
+	RemoteTriggersToCheck     int64 `json:"remote_triggers_to_check"`
+	PrometheusTriggersToCheck int64 `json:"prometheus_triggers_to_check"`
+	ScheduledNotifications    int64 `json:"scheduled_notifications"`
+}
+
+type diagnosticsWorkers struct {
+	NotifierState           string                        `json:"notifier_state"`
+	MetricsUpdates          int64                         `json:"metrics_updates"`
+	LocalChecksUpdates      int64                         `json:"local_checks_updates"`
+	RemoteChecksUpdates     int64                         `json:"remote_checks_updates"`
+	PrometheusChecksUpdates int64                         `json:"prometheus_checks_updates"`
+	Triggers                map[moira.TriggerSource]int64 `json:"triggers"`
+	SenderBlackouts         []*moira.SenderBlackout       `json:"sender_blackouts"`
+}
+
+func handleDiagnosticsBundle(logger moira.Logger, database moira.Database, bundlePath string, configPaths []string) error {
+	bundle := collectDiagnostics(database, configPaths, time.Now())
+	for _, collectionError := range bundle.errors {
+		logger.Warning().
+			String("error", collectionError).
+			Msg("Diagnostics bundle is incomplete")
+	}
+
+	file, err := os.OpenFile(bundlePath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return fmt.Errorf("cannot create diagnostics bundle file: %w", err)
+	}
+	defer closeFile(file, logger)
+
+	return bundle.write(file)
+}
+
+func collectDiagnostics(database moira.Database, configPaths []string, now time.Time) *diagnosticsBundle {
+	bundle := &diagnosticsBundle{}
+
+	bundle.addJSON("meta.json", diagnosticsMeta{
+		MoiraVersion: MoiraVersion,
+		GitCommit:    GitCommit,
+		GoVersion:    GoVersion,
+		CreatedAt:    now.UTC().Format(time.RFC3339),
+	})
+	bundle.addJSON("queues.json", bundle.collectQueues(database))
+	bundle.addJSON("workers.json", bundle.collectWorkers(database))
+
+	redisInfo, err := getRedisInfo(database)
+	if err != nil {
+		bundle.addError("redis info", err)
+	} else {
+		bundle.addFile("redis_info.txt", []byte(redisInfo))
+	}
+
+	for i, serviceName := range getServiceNames(configPaths) {
+		bundle.collectService(serviceName, configPaths[i])
+	}
+
+	if len(bundle.errors) > 0 {
+		bundle.addFile("collection_errors.txt", []byte(strings.Join(bundle.errors, "\n")+"\n"))
+	}
+	return bundle
+}
+
+func (bundle *diagnosticsBundle) collectQueues(database moira.Database) diagnosticsQueues {
+	var queues diagnosticsQueues
+	var err error
+
+	if queues.LocalTriggersToCheck, err = database.GetLocalTriggersToCheckCount(); err != nil {
+		bundle.addError("local triggers to check count", err)
+	}
+	if queues.RemoteTriggersToCheck, err = database.GetRemoteTriggersToCheckCount(); err != nil {
+		bundle.addError("remote triggers to check count", err)
+	}
+	if queues.PrometheusTriggersToCheck, err = database.GetPrometheusTriggersToCheckCount(); err != nil {
+		bundle.addError("prometheus triggers to check count", err)
+	}
+	if _, queues.ScheduledNotifications, err = database.GetNotifications(0, 0); err != nil {
+		bundle.addError("scheduled notifications count", err)
+	}
+	return queues
+}
+
+func (bundle *diagnosticsBundle) collectWorkers(database moira.Database) diagnosticsWorkers {
+	var workers diagnosticsWorkers
+	var err error
+
+	if workers.NotifierState, err = database.GetNotifierState(); err != nil {
+		bundle.addError("notifier state", err)
+	}
+	if workers.MetricsUpdates, err = database.GetMetricsUpdatesCount(); err != nil {
+		bundle.addError("metrics updates count", err)
+	}
+	if workers.LocalChecksUpdates, err = database.GetChecksUpdatesCount(); err != nil {
+		bundle.addError("local checks updates count", err)
+	}
+	if workers.RemoteChecksUpdates, err = database.GetRemoteChecksUpdatesCount(); err != nil {
+		bundle.addError("remote checks updates count", err)
+	}
+	if workers.PrometheusChecksUpdates, err = database.GetPrometheusChecksUpdatesCount(); err != nil {
+		bundle.addError("prometheus checks updates count", err)
+	}
+	if workers.Triggers, err = database.GetTriggerCount(); err != nil {
+		bundle.addError("trigger count", err)
+	}
+	if workers.SenderBlackouts, err = database.GetSenderBlackouts(); err != nil {
+		bundle.addError("sender blackouts", err)
+	}
+	return workers
+}
+
+// collectService adds redacted config of Moira service and recent errors from its log file
+// getServiceNames returns unique names of services in bundle by their config paths, the same config file names
+// are prefixed with parent directory, e.g. api/config.yml and notifier/config.yml, so bundle files are not overwritten
+func getServiceNames(configPaths []string) []string {
+	names := make([]string, len(configPaths))
+	counts := make(map[string]int, len(configPaths))
+	for i, configPath := range configPaths {
+		names[i] = strings.TrimSuffix(filepath.Base(configPath), filepath.Ext(configPath))
+		counts[names[i]]++
+	}
+
+	used := make(map[string]bool, len(configPaths))
+	for i, configPath := range configPaths {
+		name := names[i]
+		if counts[name] > 1 {
+			name = filepath.Base(filepath.Dir(configPath)) + "-" + name
+		}
+		unique := name
+		for n := 2; used[unique]; n++ {
+			unique = fmt.Sprintf("%s-%d", name, n)
+		}
+		used[unique] = true
+		names[i] = unique
+	}
+	return names
+}
+
+func (bundle *diagnosticsBundle) collectService(name, configPath string) {
+	config, err := readRedactedConfig(configPath)
+	if err != nil {
+		bundle.addError(fmt.Sprintf("config %s", configPath), err)
+		return
+	}
+
+	content, err := yaml.Marshal(config)
+	if err != nil {
+		bundle.addError(fmt.Sprintf("config %s", configPath), err)
+		return
+	}
+	bundle.addFile(fmt.Sprintf("configs/%s.yml", name), content)
+
+	logFile := getConfigLogFile(config)
+	if logFile == "" || logFile == "stdout" {
+		return
+	}
+
+	recentErrors, err := getRecentErrors(logFile)
+	if err != nil {
+		bundle.addError(fmt.Sprintf("log file %s", logFile), err)
+		return
+	}
+	bundle.addFile(fmt.Sprintf("errors/%s.log", name), []byte(strings.Join(recentErrors, "\n")+"\n"))
+}
+
+func (bundle *diagnosticsBundle) addFile(name string, content []byte) {
+	bundle.files = append(bundle.files, diagnosticsFile{name: name, content: content})
+}
+
+func (bundle *diagnosticsBundle) addJSON(name string, value interface{}) {
+	content, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		bundle.addError(name, err)
+		return
+	}
+	bundle.addFile(name, content)
+}
+
+func (bundle *diagnosticsBundle) addError(step string, err error) {
+	bundle.errors = append(bundle.errors, fmt.Sprintf("%s: %s", step, err.Error()))
+}
+
+// write writes bundle files as tar.gz archive
+func (bundle *diagnosticsBundle) write(writer io.Writer) error {
+	gzipWriter := gzip.NewWriter(writer)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	modTime := time.Now()
+	for _, file := range bundle.files {
+		header := &tar.Header{
+			Name:    file.name,
+			Mode:    0600,
+			Size:    int64(len(file.content)),
+			ModTime: modTime,
+		}
+		if err := tarWriter.WriteHeader(header); err != nil {
+			return fmt.Errorf("cannot write %s to diagnostics bundle: %w", file.name, err)
+		}
+		if _, err := tarWriter.Write(file.content); err != nil {
+			return fmt.Errorf("cannot write %s to diagnostics bundle: %w", file.name, err)
+		}
+	}
+
+	if err := tarWriter.Close(); err != nil {
+		return fmt.Errorf("cannot close diagnostics bundle: %w", err)
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return fmt.Errorf("cannot close diagnostics bundle: %w", err)
+	}
+	return nil
+}
+
+// getRedisInfo returns excerpts of Redis INFO command, for Redis cluster it returns them for every master
+func getRedisInfo(database moira.Database) (string, error) {
+	switch d := database.(type) {
+	case *redis.DbConnector:
+		switch client := d.Client().(type) {
+		case *rds.ClusterClient:
+			var builder strings.Builder
+			err := client.ForEachMaster(d.Context(), func(ctx context.Context, shard *rds.Client) error {
+				info, err := getRedisInfoSections(ctx, shard)
+				if err != nil {
+					return err
+				}
+				builder.WriteString(fmt.Sprintf("# Node %s\r\n%s", shard.Options().Addr, info))
+				return nil
+			})
+			return builder.String(), err
+		default:
+			return getRedisInfoSections(d.Context(), client)
+		}
+	default:
+		return "", makeUnknownDBError(database)
+	}
+}
+
+// getRedisInfoSections requests INFO sections one by one, because Redis before 7.0 does not support several sections at once,
+// sections unavailable on the server are marked in the result, so error is returned only if none of them is available
+func getRedisInfoSections(ctx context.Context, client rds.Cmdable) (string, error) {
+	var builder strings.Builder
+	var lastErr error
+	available := 0
+	for _, section := range redisInfoSections {
+		info, err := client.Info(ctx, section).Result()
+		if err != nil {
+			lastErr = err
+			builder.WriteString(fmt.Sprintf("# Section %s is not available: %s\r\n", section, err.Error()))
+			continue
+		}
+		available++
+		builder.WriteString(info)
+		builder.WriteString("\r\n")
+	}
+	if available == 0 {
+		return "", lastErr
+	}
+	return builder.String(), nil
+}
+
+func readRedactedConfig(configPath string) (map[interface{}]interface{}, error) {
+	content, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	config := make(map[interface{}]interface{})
+	if err := yaml.Unmarshal(content, &config); err != nil {
+		return nil, fmt.Errorf("cannot parse config: %w", err)
+	}
+
+	redactSecrets(config)
+	return config, nil
+}
+
+// redactSecrets replaces values of secret config keys and passwords in URLs, including the nested ones
+func redactSecrets(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		for key, item := range v {
+			if isSecretKey(fmt.Sprint(key)) && isSecretValue(item) {
+				v[key] = redactedValue
+				continue
+			}
+			if urlValue, ok := item.(string); ok && isURLKey(fmt.Sprint(key)) {
+				v[key] = redactURL(urlValue)
+				continue
+			}
+			v[key] = redactSecrets(item)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactSecrets(item)
+		}
+	case string:
+		if parsedURL, err := url.Parse(v); err == nil && parsedURL.User != nil {
+			// secret can be passed as user name too, e.g. https://<token>@host
+			parsedURL.User = nil
+			return strings.Replace(parsedURL.String(), "//", "//"+redactedValue+"@", 1)
+		}
+	}
+	return value
+}
+
+// isSecretValue checks if value can hold a secret, flags and nested settings are kept as is
+func isSecretValue(value interface{}) bool {
+	switch v := value.(type) {
+	case string:
+		return v != ""
+	case int, int64, float64:
+		return true
+	default:
+		return false
+	}
+}
+
+func isSecretKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, part := range secretKeyParts {
+		if strings.Contains(key, part) {
+			return true
+		}
+	}
+	return false
+}
+
+// isURLKey checks if key holds URL, which path or query can contain a secret, e.g. VictorOps routing url
+func isURLKey(key string) bool {
+	key = strings.ToLower(key)
+	return key == "url" || strings.HasSuffix(key, "_url")
+}
+
+// redactURL keeps only scheme and host of URL, user info, path and query are redacted
+func redactURL(value string) string {
+	parsedURL, err := url.Parse(value)
+	if err != nil || parsedURL.Host == "" {
+		if value == "" {
+			return value
+		}
+		return redactedValue
+	}
+
+	redacted := parsedURL.Scheme + "://" + parsedURL.Host
+	if parsedURL.User != nil || strings.Trim(parsedURL.Path, "/") != "" || parsedURL.RawQuery != "" || parsedURL.Fragment != "" {
+		redacted += "/" + redactedValue
+	}
+	return redacted
+}
+
+// getConfigLogFile returns log file of Moira service config, CLI config has it at the top level
+func getConfigLogFile(config map[interface{}]interface{}) string {
+	if logConfig, ok := config["log"].(map[interface{}]interface{}); ok {
+		if logFile, ok := logConfig["log_file"].(string); ok {
+			return logFile
+		}
+	}
+	logFile, _ := config["log_file"].(string)
+	return logFile
+}
+
+// getRecentErrors returns the last error and fatal log lines from the ending of log file
+func getRecentErrors(logFile string) ([]string, error) {
+	file, err := os.Open(logFile)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	offset := info.Size() - logTailSize
+	if offset > 0 {
+		if _, err := file.Seek(offset, io.SeekStart); err != nil {
+			return nil, err
+		}
+	}
+
+	recentErrors := make([]string, 0)
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), logTailSize)
+	for skipPartialLine := offset > 0; scanner.Scan(); skipPartialLine = false {
+		line := scanner.Text()
+		if skipPartialLine || !isErrorLogLine(line) {
+			continue
+		}
+		recentErrors = append(recentErrors, line)
+		if len(recentErrors) > recentErrorsLimit {
+			recentErrors = recentErrors[1:]
+		}
+	}
+	return recentErrors, scanner.Err()
+}
+
+// isErrorLogLine checks if log line has error or fatal level in JSON or pretty log format
+func isErrorLogLine(line string) bool {
+	return strings.Contains(line, `"level":"error"`) ||
+		strings.Contains(line, `"level":"fatal"`) ||
+		strings.Contains(line, " ERR ") ||
+		strings.Contains(line, " FTL ")
+}
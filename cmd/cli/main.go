@@ -75,6 +75,11 @@ var (
 	removeUnusedTriggersStartWith = flag.String("remove-unused-triggers-start-with", "", "Remove unused triggers which have ID starting with string parameter")
 )
 
+var (
+	diagnosticsBundlePath  = flag.String("diagnostics-bundle", "", "Collect diagnostics bundle for support requests into given tar.gz archive")
+	diagnosticsConfigPaths = flag.String("diagnostics-configs", "", "Config files of Moira services separated by commas to add into diagnostics bundle with secrets redacted, recent errors are taken from their log files")
+)
+
 func main() { //nolint
 	confCleanup, logger, database := initApp()
 
@@ -325,6 +330,27 @@ func main() { //nolint
 		logger.Info().Msg("Dump was pushed")
 	}
 
+	if *diagnosticsBundlePath != "" {
+		log := logger.String(moira.LogFieldNameContext, "diagnostics-bundle")
+		var configPaths []string
+		if *diagnosticsConfigPaths != "" {
+			for _, configPath := range strings.Split(*diagnosticsConfigPaths, ",") {
+				configPaths = append(configPaths, strings.TrimSpace(configPath))
+			}
+		}
+
+		log.Info().Msg("Collecting diagnostics bundle started")
+		if err := handleDiagnosticsBundle(log, database, *diagnosticsBundlePath, configPaths); err != nil {
+			log.Error().
+				Error(err).
+				Msg("Failed to collect diagnostics bundle")
+		} else {
+			log.Info().
+				String("bundle", *diagnosticsBundlePath).
+				Msg("Collecting diagnostics bundle finished")
+		}
+	}
+
 	if *removeSubscriptions != "" {
 		logger.Info().Msg("Start deletion of subscriptions")
 		subscriptionIDs := strings.Split(*removeSubscriptions, ";")
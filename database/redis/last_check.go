@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -37,6 +38,7 @@ func (connector *DbConnector) SetTriggerLastCheck(triggerID string, checkData *m
 	}
 
 	triggerNeedToReindex := connector.checkDataScoreChanged(triggerID, checkData)
+	triggerStateChanged := connector.checkDataStateChanged(triggerID, checkData)
 
 	ctx := connector.context
 	pipe := (*connector.client).TxPipeline()
@@ -57,6 +59,12 @@ func (connector *DbConnector) SetTriggerLastCheck(triggerID string, checkData *m
 		pipe.ZAdd(ctx, triggersToReindexKey, &redis.Z{Score: float64(time.Now().Unix()), Member: triggerID})
 	}
 
+	if triggerStateChanged {
+		pipe.HSet(ctx, triggersStatesKey, triggerID, string(checkData.State))
+		pipe.HSet(ctx, triggersStateChangeTimestampsKey, triggerID, checkData.Timestamp)
+		addTriggerStateChangeScript.Eval(ctx, pipe, []string{triggersStateChangesSeqKey, triggersStateChangesKey}, triggerID)
+	}
+
 	_, err = pipe.Exec(ctx)
 
 	if err != nil {
@@ -90,6 +98,10 @@ func appendRemoveTriggerLastCheckToRedisPipeline(ctx context.Context, pipe redis
 	pipe.ZRem(ctx, triggersChecksKey, triggerID)
 	pipe.SRem(ctx, badStateTriggersKey, triggerID)
 	pipe.ZAdd(ctx, triggersToReindexKey, &redis.Z{Score: float64(time.Now().Unix()), Member: triggerID})
+	// Deletion is the last change of trigger state, trigger without state is returned as deleted one
+	pipe.HDel(ctx, triggersStatesKey, triggerID)
+	pipe.HSet(ctx, triggersStateChangeTimestampsKey, triggerID, time.Now().Unix())
+	addTriggerStateChangeScript.Eval(ctx, pipe, []string{triggersStateChangesSeqKey, triggersStateChangesKey}, triggerID)
 
 	return pipe
 }
@@ -199,8 +211,73 @@ func (connector *DbConnector) checkDataScoreChanged(triggerID string, checkData
 	return oldScore != float64(checkData.Score)
 }
 
-// getTriggersLastCheck returns an array of trigger checks by the passed ids, if the trigger does not exist, it is nil
-func (connector *DbConnector) getTriggersLastCheck(triggerIDs []string) ([]*moira.CheckData, error) {
+// checkDataStateChanged returns true if checkData.State changed since last check
+func (connector *DbConnector) checkDataStateChanged(triggerID string, checkData *moira.CheckData) bool {
+	ctx := connector.context
+	c := *connector.client
+
+	oldState, err := c.HGet(ctx, triggersStatesKey, triggerID).Result()
+	if err != nil {
+		return true
+	}
+
+	return oldState != string(checkData.State)
+}
+
+// GetTriggersStateChanges returns the last state changes of triggers whose state was changed or which were deleted
+// after the change with given sequence number
+func (connector *DbConnector) GetTriggersStateChanges(from int64) ([]*moira.TriggerStateChange, error) {
+	ctx := connector.context
+	c := *connector.client
+
+	changes, err := c.ZRangeByScoreWithScores(ctx, triggersStateChangesKey, &redis.ZRangeBy{
+		Min: "(" + strconv.FormatInt(from, 10),
+		Max: "+inf",
+	}).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get triggers state changes: %s", err.Error())
+	}
+
+	if len(changes) == 0 {
+		return make([]*moira.TriggerStateChange, 0), nil
+	}
+
+	triggerIDs := make([]string, 0, len(changes))
+	for _, change := range changes {
+		triggerIDs = append(triggerIDs, change.Member.(string))
+	}
+
+	pipe := c.TxPipeline()
+	statesCmd := pipe.HMGet(ctx, triggersStatesKey, triggerIDs...)
+	timestampsCmd := pipe.HMGet(ctx, triggersStateChangeTimestampsKey, triggerIDs...)
+	if _, err = pipe.Exec(ctx); err != nil {
+		return nil, fmt.Errorf("failed to get triggers states: %s", err.Error())
+	}
+	states := statesCmd.Val()
+	timestamps := timestampsCmd.Val()
+
+	result := make([]*moira.TriggerStateChange, 0, len(changes))
+	for i, change := range changes {
+		stateChange := &moira.TriggerStateChange{
+			TriggerID: triggerIDs[i],
+			Sequence:  int64(change.Score),
+		}
+		if state, ok := states[i].(string); ok {
+			stateChange.State = moira.State(state)
+		} else {
+			stateChange.Deleted = true
+		}
+		if timestamp, ok := timestamps[i].(string); ok {
+			stateChange.Timestamp, _ = strconv.ParseInt(timestamp, 10, 64)
+		}
+		result = append(result, stateChange)
+	}
+
+	return result, nil
+}
+
+// GetTriggersLastCheck returns an array of trigger checks by the passed ids, if the trigger does not exist, it is nil
+func (connector *DbConnector) GetTriggersLastCheck(triggerIDs []string) ([]*moira.CheckData, error) {
 	ctx := connector.context
 	pipe := (*connector.client).TxPipeline()
 
@@ -223,6 +300,18 @@ func (connector *DbConnector) getTriggersLastCheck(triggerIDs []string) ([]*moir
 
 var badStateTriggersKey = "moira-bad-state-triggers"
 var triggersChecksKey = "moira-triggers-checks"
+var triggersStatesKey = "moira-triggers-states"
+var triggersStateChangeTimestampsKey = "moira-triggers-state-change-timestamps"
+var triggersStateChangesKey = "{moira-triggers-state-changes}:moira-triggers-state-changes"
+var triggersStateChangesSeqKey = "{moira-triggers-state-changes}:moira-triggers-state-changes-seq"
+
+// addTriggerStateChangeScript numbers trigger state change on the Redis side at the moment of commit,
+// so the change committed later always gets the greater number regardless of checkers clocks
+var addTriggerStateChangeScript = redis.NewScript(`
+local seq = redis.call("INCR", KEYS[1])
+redis.call("ZADD", KEYS[2], seq, ARGV[1])
+return seq
+`)
 
 func metricLastCheckKey(triggerID string) string {
 	return "moira-metric-last-check:" + triggerID
@@ -265,6 +265,100 @@ func TestLastCheck(t *testing.T) {
 			So(actual, ShouldResemble, []string{triggerID})
 		})
 
+		Convey("Test last check manipulations update triggers state changes", func() {
+			dataBase.Flush()
+			triggerID := uuid.Must(uuid.NewV4()).String()
+			errorLastCheck := lastCheckWithNoMetrics
+			errorLastCheck.State = moira.StateERROR
+
+			// there was no trigger with such ID, so function should return true
+			So(dataBase.checkDataStateChanged(triggerID, &lastCheckWithNoMetrics), ShouldBeTrue)
+
+			err := dataBase.SetTriggerLastCheck(triggerID, &lastCheckWithNoMetrics, moira.GraphiteLocal)
+			So(err, ShouldBeNil)
+
+			So(dataBase.checkDataStateChanged(triggerID, &lastCheckWithNoMetrics), ShouldBeFalse)
+			So(dataBase.checkDataStateChanged(triggerID, &errorLastCheck), ShouldBeTrue)
+
+			actual, err := dataBase.GetTriggersStateChanges(0)
+			So(err, ShouldBeNil)
+			So(actual, ShouldHaveLength, 1)
+			So(actual[0].TriggerID, ShouldEqual, triggerID)
+			So(actual[0].State, ShouldEqual, moira.StateOK)
+			So(actual[0].Timestamp, ShouldEqual, lastCheckWithNoMetrics.Timestamp)
+			So(actual[0].Deleted, ShouldBeFalse)
+
+			checkpoint := actual[0].Sequence
+			actual, err = dataBase.GetTriggersStateChanges(checkpoint)
+			So(err, ShouldBeNil)
+			So(actual, ShouldBeEmpty)
+
+			err = dataBase.SetTriggerLastCheck(triggerID, &errorLastCheck, moira.GraphiteLocal)
+			So(err, ShouldBeNil)
+
+			actual, err = dataBase.GetTriggersStateChanges(checkpoint)
+			So(err, ShouldBeNil)
+			So(actual, ShouldHaveLength, 1)
+			So(actual[0].TriggerID, ShouldEqual, triggerID)
+			So(actual[0].State, ShouldEqual, moira.StateERROR)
+			So(actual[0].Timestamp, ShouldEqual, errorLastCheck.Timestamp)
+			So(actual[0].Sequence, ShouldBeGreaterThan, checkpoint)
+
+			checkpoint = actual[0].Sequence
+			err = dataBase.RemoveTriggerLastCheck(triggerID)
+			So(err, ShouldBeNil)
+
+			actual, err = dataBase.GetTriggersStateChanges(checkpoint)
+			So(err, ShouldBeNil)
+			So(actual, ShouldHaveLength, 1)
+			So(actual[0].TriggerID, ShouldEqual, triggerID)
+			So(actual[0].Deleted, ShouldBeTrue)
+			So(actual[0].State, ShouldBeEmpty)
+			So(actual[0].Timestamp, ShouldBeGreaterThanOrEqualTo, time.Now().Unix()-1)
+			So(actual[0].Sequence, ShouldBeGreaterThan, checkpoint)
+
+			// restored trigger gets its state back with the next check
+			checkpoint = actual[0].Sequence
+			So(dataBase.checkDataStateChanged(triggerID, &errorLastCheck), ShouldBeTrue)
+			err = dataBase.SetTriggerLastCheck(triggerID, &errorLastCheck, moira.GraphiteLocal)
+			So(err, ShouldBeNil)
+
+			actual, err = dataBase.GetTriggersStateChanges(checkpoint)
+			So(err, ShouldBeNil)
+			So(actual, ShouldHaveLength, 1)
+			So(actual[0].Deleted, ShouldBeFalse)
+			So(actual[0].State, ShouldEqual, moira.StateERROR)
+		})
+
+		Convey("Test state change committed after read is not skipped", func() {
+			dataBase.Flush()
+			triggerID1 := uuid.Must(uuid.NewV4()).String()
+			triggerID2 := uuid.Must(uuid.NewV4()).String()
+
+			lateLastCheck := lastCheckWithNoMetrics
+			lateLastCheck.State = moira.StateERROR
+			lateLastCheck.Timestamp = lastCheckWithNoMetrics.Timestamp - 60
+
+			err := dataBase.SetTriggerLastCheck(triggerID1, &lastCheckWithNoMetrics, moira.GraphiteLocal)
+			So(err, ShouldBeNil)
+
+			actual, err := dataBase.GetTriggersStateChanges(0)
+			So(err, ShouldBeNil)
+			So(actual, ShouldHaveLength, 1)
+			checkpoint := actual[0].Sequence
+
+			// check of the second trigger started earlier, but is committed after the read
+			err = dataBase.SetTriggerLastCheck(triggerID2, &lateLastCheck, moira.GraphiteLocal)
+			So(err, ShouldBeNil)
+
+			actual, err = dataBase.GetTriggersStateChanges(checkpoint)
+			So(err, ShouldBeNil)
+			So(actual, ShouldHaveLength, 1)
+			So(actual[0].TriggerID, ShouldEqual, triggerID2)
+			So(actual[0].State, ShouldEqual, moira.StateERROR)
+			So(actual[0].Sequence, ShouldBeGreaterThan, checkpoint)
+		})
+
 		Convey("Test populate metric values", func() {
 			value := float64(1)
 			triggerID := uuid.Must(uuid.NewV4()).String()
@@ -487,15 +581,15 @@ func TestGetTriggersLastCheck(t *testing.T) {
 		Timestamp: 3,
 	}, moira.TriggerSourceNotSet)
 
-	Convey("getTriggersLastCheck manipulations", t, func() {
+	Convey("GetTriggersLastCheck manipulations", t, func() {
 		Convey("Test with nil id array", func() {
-			actual, err := dataBase.getTriggersLastCheck(nil)
+			actual, err := dataBase.GetTriggersLastCheck(nil)
 			So(err, ShouldBeNil)
 			So(actual, ShouldResemble, []*moira.CheckData{})
 		})
 
 		Convey("Test with correct id array", func() {
-			actual, err := dataBase.getTriggersLastCheck([]string{"test1", "test2", "test3"})
+			actual, err := dataBase.GetTriggersLastCheck([]string{"test1", "test2", "test3"})
 			So(err, ShouldBeNil)
 			So(actual, ShouldResemble, []*moira.CheckData{
 				{
@@ -521,7 +615,7 @@ func TestGetTriggersLastCheck(t *testing.T) {
 				}, moira.TriggerSourceNotSet)
 			}()
 
-			actual, err := dataBase.getTriggersLastCheck([]string{"test1", "test2", "test3"})
+			actual, err := dataBase.GetTriggersLastCheck([]string{"test1", "test2", "test3"})
 			So(err, ShouldBeNil)
 			So(actual, ShouldResemble, []*moira.CheckData{
 				{
@@ -537,7 +631,7 @@ func TestGetTriggersLastCheck(t *testing.T) {
 		})
 
 		Convey("Test with a nonexistent trigger id", func() {
-			actual, err := dataBase.getTriggersLastCheck([]string{"test1", "test2", "test4"})
+			actual, err := dataBase.GetTriggersLastCheck([]string{"test1", "test2", "test4"})
 			So(err, ShouldBeNil)
 			So(actual, ShouldResemble, []*moira.CheckData{
 				{
@@ -553,7 +647,7 @@ func TestGetTriggersLastCheck(t *testing.T) {
 		})
 
 		Convey("Test with an empty trigger id", func() {
-			actual, err := dataBase.getTriggersLastCheck([]string{"", "test2", "test3"})
+			actual, err := dataBase.GetTriggersLastCheck([]string{"", "test2", "test3"})
 			So(err, ShouldBeNil)
 			So(actual, ShouldResemble, []*moira.CheckData{
 				nil,
@@ -170,7 +170,7 @@ func (connector *DbConnector) getNotificationsTriggerChecks(notifications []*moi
 		triggerIDs = append(triggerIDs, triggerID)
 	}
 
-	triggersLastCheck, err := connector.getTriggersLastCheck(triggerIDs)
+	triggersLastCheck, err := connector.GetTriggersLastCheck(triggerIDs)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,162 @@
+package redis
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/database/redis/reply"
+)
+
+// AddTriggerToRecycleBin saves deleted trigger to the recycle bin, it will be removed from there after given ttl
+func (connector *DbConnector) AddTriggerToRecycleBin(deletedTrigger *moira.DeletedTrigger, ttl time.Duration) error {
+	bytes, err := json.Marshal(deletedTrigger)
+	if err != nil {
+		return fmt.Errorf("failed to marshal deleted trigger: %s", err.Error())
+	}
+
+	triggerID := deletedTrigger.Trigger.ID
+	return connector.addToRecycleBin(recycleBinTriggerKey(triggerID), recycleBinTriggersKey, triggerID, bytes, ttl)
+}
+
+// GetRecycleBinTrigger returns deleted trigger from the recycle bin, if no value, return database.ErrNil error
+func (connector *DbConnector) GetRecycleBinTrigger(triggerID string) (moira.DeletedTrigger, error) {
+	c := *connector.client
+	return reply.DeletedTrigger(c.Get(connector.context, recycleBinTriggerKey(triggerID)))
+}
+
+// GetRecycleBinTriggers returns all triggers from the recycle bin ordered by time of removal
+func (connector *DbConnector) GetRecycleBinTriggers() ([]*moira.DeletedTrigger, error) {
+	triggerIDs, err := connector.getRecycleBinIDs(recycleBinTriggersKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(triggerIDs) == 0 {
+		return make([]*moira.DeletedTrigger, 0), nil
+	}
+
+	pipe := (*connector.client).TxPipeline()
+	results := make([]*redis.StringCmd, 0, len(triggerIDs))
+	for _, triggerID := range triggerIDs {
+		results = append(results, pipe.Get(connector.context, recycleBinTriggerKey(triggerID)))
+	}
+
+	if _, err = pipe.Exec(connector.context); err != nil && !errors.Is(err, redis.Nil) {
+		return nil, fmt.Errorf("failed to EXEC: %s", err.Error())
+	}
+
+	return reply.DeletedTriggers(results)
+}
+
+// RemoveTriggerFromRecycleBin removes trigger from the recycle bin
+func (connector *DbConnector) RemoveTriggerFromRecycleBin(triggerID string) error {
+	return connector.removeFromRecycleBin(recycleBinTriggerKey(triggerID), recycleBinTriggersKey, triggerID)
+}
+
+// AddSubscriptionToRecycleBin saves deleted subscription to the recycle bin, it will be removed from there after given ttl
+func (connector *DbConnector) AddSubscriptionToRecycleBin(deletedSubscription *moira.DeletedSubscription, ttl time.Duration) error {
+	bytes, err := json.Marshal(deletedSubscription)
+	if err != nil {
+		return fmt.Errorf("failed to marshal deleted subscription: %s", err.Error())
+	}
+
+	subscriptionID := deletedSubscription.Subscription.ID
+	return connector.addToRecycleBin(recycleBinSubscriptionKey(subscriptionID), recycleBinSubscriptionsKey, subscriptionID, bytes, ttl)
+}
+
+// GetRecycleBinSubscription returns deleted subscription from the recycle bin, if no value, return database.ErrNil error
+func (connector *DbConnector) GetRecycleBinSubscription(subscriptionID string) (moira.DeletedSubscription, error) {
+	c := *connector.client
+	return reply.DeletedSubscription(c.Get(connector.context, recycleBinSubscriptionKey(subscriptionID)))
+}
+
+// GetRecycleBinSubscriptions returns all subscriptions from the recycle bin ordered by time of removal
+func (connector *DbConnector) GetRecycleBinSubscriptions() ([]*moira.DeletedSubscription, error) {
+	subscriptionIDs, err := connector.getRecycleBinIDs(recycleBinSubscriptionsKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(subscriptionIDs) == 0 {
+		return make([]*moira.DeletedSubscription, 0), nil
+	}
+
+	pipe := (*connector.client).TxPipeline()
+	results := make([]*redis.StringCmd, 0, len(subscriptionIDs))
+	for _, subscriptionID := range subscriptionIDs {
+		results = append(results, pipe.Get(connector.context, recycleBinSubscriptionKey(subscriptionID)))
+	}
+
+	if _, err = pipe.Exec(connector.context); err != nil && !errors.Is(err, redis.Nil) {
+		return nil, fmt.Errorf("failed to EXEC: %s", err.Error())
+	}
+
+	return reply.DeletedSubscriptions(results)
+}
+
+// RemoveSubscriptionFromRecycleBin removes subscription from the recycle bin
+func (connector *DbConnector) RemoveSubscriptionFromRecycleBin(subscriptionID string) error {
+	return connector.removeFromRecycleBin(recycleBinSubscriptionKey(subscriptionID), recycleBinSubscriptionsKey, subscriptionID)
+}
+
+// addToRecycleBin stores object with expiration and adds it's ID to the recycle bin index scored by expiration time
+func (connector *DbConnector) addToRecycleBin(key, indexKey, id string, bytes []byte, ttl time.Duration) error {
+	ctx := connector.context
+	expiresAt := time.Now().Add(ttl).Unix()
+
+	pipe := (*connector.client).TxPipeline()
+	pipe.Set(ctx, key, bytes, ttl)
+	pipe.ZAdd(ctx, indexKey, &redis.Z{Score: float64(expiresAt), Member: id})
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to EXEC: %s", err.Error())
+	}
+
+	return nil
+}
+
+// getRecycleBinIDs cleans up expired IDs from the recycle bin index and returns the rest ones
+func (connector *DbConnector) getRecycleBinIDs(indexKey string) ([]string, error) {
+	ctx := connector.context
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+
+	pipe := (*connector.client).TxPipeline()
+	pipe.ZRemRangeByScore(ctx, indexKey, "-inf", now)
+	ids := pipe.ZRange(ctx, indexKey, 0, -1)
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, fmt.Errorf("failed to EXEC: %s", err.Error())
+	}
+
+	return ids.Val(), nil
+}
+
+func (connector *DbConnector) removeFromRecycleBin(key, indexKey, id string) error {
+	ctx := connector.context
+
+	pipe := (*connector.client).TxPipeline()
+	pipe.Del(ctx, key)
+	pipe.ZRem(ctx, indexKey, id)
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to EXEC: %s", err.Error())
+	}
+
+	return nil
+}
+
+var recycleBinTriggersKey = "moira-recycle-bin-triggers"
+var recycleBinSubscriptionsKey = "moira-recycle-bin-subscriptions"
+
+func recycleBinTriggerKey(triggerID string) string {
+	return "moira-recycle-bin-trigger:" + triggerID
+}
+
+func recycleBinSubscriptionKey(subscriptionID string) string {
+	return "moira-recycle-bin-subscription:" + subscriptionID
+}
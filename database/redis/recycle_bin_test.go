@@ -0,0 +1,108 @@
+package redis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/database"
+	logging "github.com/moira-alert/moira/logging/zerolog_adapter"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestRecycleBin(t *testing.T) {
+	logger, _ := logging.GetLogger("dataBase")
+	dataBase := NewTestDatabase(logger)
+	dataBase.Flush()
+	defer dataBase.Flush()
+
+	Convey("Triggers recycle bin manipulation", t, func() {
+		deletedTrigger := &moira.DeletedTrigger{
+			Trigger:   moira.Trigger{ID: "trigger1", Name: "trigger", Tags: []string{"tag"}, Targets: []string{"t1"}, Patterns: []string{}},
+			DeletedBy: "user",
+			DeletedAt: 1590741878,
+		}
+
+		Convey("Get deleted trigger when recycle bin is empty", func() {
+			actual, err := dataBase.GetRecycleBinTrigger(deletedTrigger.Trigger.ID)
+			So(err, ShouldResemble, database.ErrNil)
+			So(actual, ShouldResemble, moira.DeletedTrigger{})
+
+			list, err := dataBase.GetRecycleBinTriggers()
+			So(err, ShouldBeNil)
+			So(list, ShouldBeEmpty)
+		})
+
+		Convey("Add, get and remove deleted trigger", func() {
+			err := dataBase.AddTriggerToRecycleBin(deletedTrigger, time.Hour)
+			So(err, ShouldBeNil)
+
+			actual, err := dataBase.GetRecycleBinTrigger(deletedTrigger.Trigger.ID)
+			So(err, ShouldBeNil)
+			So(actual, ShouldResemble, *deletedTrigger)
+
+			list, err := dataBase.GetRecycleBinTriggers()
+			So(err, ShouldBeNil)
+			So(list, ShouldResemble, []*moira.DeletedTrigger{deletedTrigger})
+
+			err = dataBase.RemoveTriggerFromRecycleBin(deletedTrigger.Trigger.ID)
+			So(err, ShouldBeNil)
+
+			_, err = dataBase.GetRecycleBinTrigger(deletedTrigger.Trigger.ID)
+			So(err, ShouldResemble, database.ErrNil)
+
+			list, err = dataBase.GetRecycleBinTriggers()
+			So(err, ShouldBeNil)
+			So(list, ShouldBeEmpty)
+		})
+
+		Convey("Expired triggers are not listed", func() {
+			err := dataBase.AddTriggerToRecycleBin(deletedTrigger, time.Second)
+			So(err, ShouldBeNil)
+
+			time.Sleep(time.Second * 2)
+
+			list, err := dataBase.GetRecycleBinTriggers()
+			So(err, ShouldBeNil)
+			So(list, ShouldBeEmpty)
+		})
+	})
+
+	Convey("Subscriptions recycle bin manipulation", t, func() {
+		deletedSubscription := &moira.DeletedSubscription{
+			Subscription: moira.SubscriptionData{ID: "subscription1", User: "user", Tags: []string{"tag"}, Contacts: []string{"contact"}},
+			DeletedBy:    "user",
+			DeletedAt:    1590741878,
+		}
+
+		Convey("Get deleted subscription when recycle bin is empty", func() {
+			actual, err := dataBase.GetRecycleBinSubscription(deletedSubscription.Subscription.ID)
+			So(err, ShouldResemble, database.ErrNil)
+			So(actual, ShouldResemble, moira.DeletedSubscription{})
+
+			list, err := dataBase.GetRecycleBinSubscriptions()
+			So(err, ShouldBeNil)
+			So(list, ShouldBeEmpty)
+		})
+
+		Convey("Add, get and remove deleted subscription", func() {
+			err := dataBase.AddSubscriptionToRecycleBin(deletedSubscription, time.Hour)
+			So(err, ShouldBeNil)
+
+			actual, err := dataBase.GetRecycleBinSubscription(deletedSubscription.Subscription.ID)
+			So(err, ShouldBeNil)
+			So(actual, ShouldResemble, *deletedSubscription)
+
+			list, err := dataBase.GetRecycleBinSubscriptions()
+			So(err, ShouldBeNil)
+			So(list, ShouldResemble, []*moira.DeletedSubscription{deletedSubscription})
+
+			err = dataBase.RemoveSubscriptionFromRecycleBin(deletedSubscription.Subscription.ID)
+			So(err, ShouldBeNil)
+
+			list, err = dataBase.GetRecycleBinSubscriptions()
+			So(err, ShouldBeNil)
+			So(list, ShouldBeEmpty)
+		})
+	})
+}
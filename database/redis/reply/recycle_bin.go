@@ -0,0 +1,77 @@
+package reply
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/database"
+)
+
+// DeletedTrigger converts redis DB reply to moira.DeletedTrigger object
+func DeletedTrigger(rep *redis.StringCmd) (moira.DeletedTrigger, error) {
+	deletedTrigger := moira.DeletedTrigger{}
+	bytes, err := rep.Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return deletedTrigger, database.ErrNil
+		}
+		return deletedTrigger, fmt.Errorf("failed to read deleted trigger: %s", err.Error())
+	}
+	err = json.Unmarshal(bytes, &deletedTrigger)
+	if err != nil {
+		return deletedTrigger, fmt.Errorf("failed to parse deleted trigger json %s: %s", string(bytes), err.Error())
+	}
+	return deletedTrigger, nil
+}
+
+// DeletedTriggers converts redis DB replies to moira.DeletedTrigger objects array, skipping the expired ones
+func DeletedTriggers(replies []*redis.StringCmd) ([]*moira.DeletedTrigger, error) {
+	result := make([]*moira.DeletedTrigger, 0, len(replies))
+	for _, value := range replies {
+		item, err := DeletedTrigger(value)
+		if err != nil {
+			if errors.Is(err, database.ErrNil) {
+				continue
+			}
+			return nil, err
+		}
+		result = append(result, &item)
+	}
+	return result, nil
+}
+
+// DeletedSubscription converts redis DB reply to moira.DeletedSubscription object
+func DeletedSubscription(rep *redis.StringCmd) (moira.DeletedSubscription, error) {
+	deletedSubscription := moira.DeletedSubscription{}
+	bytes, err := rep.Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return deletedSubscription, database.ErrNil
+		}
+		return deletedSubscription, fmt.Errorf("failed to read deleted subscription: %s", err.Error())
+	}
+	err = json.Unmarshal(bytes, &deletedSubscription)
+	if err != nil {
+		return deletedSubscription, fmt.Errorf("failed to parse deleted subscription json %s: %s", string(bytes), err.Error())
+	}
+	return deletedSubscription, nil
+}
+
+// DeletedSubscriptions converts redis DB replies to moira.DeletedSubscription objects array, skipping the expired ones
+func DeletedSubscriptions(replies []*redis.StringCmd) ([]*moira.DeletedSubscription, error) {
+	result := make([]*moira.DeletedSubscription, 0, len(replies))
+	for _, value := range replies {
+		item, err := DeletedSubscription(value)
+		if err != nil {
+			if errors.Is(err, database.ErrNil) {
+				continue
+			}
+			return nil, err
+		}
+		result = append(result, &item)
+	}
+	return result, nil
+}
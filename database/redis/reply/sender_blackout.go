@@ -0,0 +1,34 @@
+package reply
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/moira-alert/moira"
+)
+
+// SenderBlackouts converts redis DB reply to moira.SenderBlackout objects array ordered by contact type,
+// broken blackouts are skipped, so they do not affect other senders
+func SenderBlackouts(rep *redis.StringStringMapCmd) ([]*moira.SenderBlackout, error) {
+	values, err := rep.Result()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return nil, fmt.Errorf("failed to read sender blackouts: %s", err.Error())
+	}
+
+	blackouts := make([]*moira.SenderBlackout, 0, len(values))
+	for _, value := range values {
+		blackout := &moira.SenderBlackout{}
+		if err = json.Unmarshal([]byte(value), blackout); err != nil {
+			continue
+		}
+		blackouts = append(blackouts, blackout)
+	}
+
+	sort.Slice(blackouts, func(i, j int) bool {
+		return blackouts[i].ContactType < blackouts[j].ContactType
+	})
+	return blackouts, nil
+}
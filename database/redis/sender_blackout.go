@@ -0,0 +1,78 @@
+package redis
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/database/redis/reply"
+)
+
+// GetSenderBlackouts returns active sender blackouts, the finished ones are removed from the database
+func (connector *DbConnector) GetSenderBlackouts() ([]*moira.SenderBlackout, error) {
+	ctx := connector.context
+	c := *connector.client
+	now := time.Now().Unix()
+
+	var active []*moira.SenderBlackout
+	// finished blackouts are removed within the transaction, so the blackout set again in the meantime is not removed
+	err := c.Watch(ctx, func(tx *redis.Tx) error {
+		blackouts, err := reply.SenderBlackouts(tx.HGetAll(ctx, senderBlackoutsKey))
+		if err != nil {
+			return err
+		}
+
+		active = make([]*moira.SenderBlackout, 0, len(blackouts))
+		finished := make([]string, 0)
+		for _, blackout := range blackouts {
+			if blackout.IsActive(now) {
+				active = append(active, blackout)
+			} else {
+				finished = append(finished, blackout.ContactType)
+			}
+		}
+
+		if len(finished) == 0 {
+			return nil
+		}
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.HDel(ctx, senderBlackoutsKey, finished...)
+			return nil
+		})
+		return err
+	}, senderBlackoutsKey)
+
+	// blackouts were changed while removing finished ones, they are removed at the next call
+	if err != nil && !errors.Is(err, redis.TxFailedErr) {
+		return nil, err
+	}
+	return active, nil
+}
+
+// SetSenderBlackout saves sender blackout, replacing existing one for the same contact type
+func (connector *DbConnector) SetSenderBlackout(blackout *moira.SenderBlackout) error {
+	bytes, err := json.Marshal(blackout)
+	if err != nil {
+		return fmt.Errorf("failed to marshal sender blackout: %s", err.Error())
+	}
+
+	c := *connector.client
+	if err := c.HSet(connector.context, senderBlackoutsKey, blackout.ContactType, bytes).Err(); err != nil {
+		return fmt.Errorf("failed to save sender blackout: %s", err.Error())
+	}
+	return nil
+}
+
+// RemoveSenderBlackout removes sender blackout for given contact type
+func (connector *DbConnector) RemoveSenderBlackout(contactType string) error {
+	c := *connector.client
+	if err := c.HDel(connector.context, senderBlackoutsKey, contactType).Err(); err != nil {
+		return fmt.Errorf("failed to remove sender blackout: %s", err.Error())
+	}
+	return nil
+}
+
+var senderBlackoutsKey = "moira-sender-blackouts"
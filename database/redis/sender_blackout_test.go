@@ -0,0 +1,123 @@
+package redis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/moira-alert/moira"
+	logging "github.com/moira-alert/moira/logging/zerolog_adapter"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSenderBlackouts(t *testing.T) {
+	logger, _ := logging.GetLogger("dataBase")
+	dataBase := NewTestDatabase(logger)
+	dataBase.Flush()
+	defer dataBase.Flush()
+
+	Convey("Sender blackouts manipulation", t, func() {
+		slackBlackout := &moira.SenderBlackout{
+			ContactType: "slack",
+			Mode:        moira.SenderBlackoutModeFallback,
+			CreatedBy:   "user",
+			CreatedAt:   1590741878,
+		}
+		mailBlackout := &moira.SenderBlackout{
+			ContactType: "mail",
+			Mode:        moira.SenderBlackoutModeQueue,
+			Until:       time.Now().Add(time.Hour).Unix(),
+			CreatedBy:   "user",
+			CreatedAt:   1590741878,
+		}
+
+		Convey("Get blackouts on empty database", func() {
+			blackouts, err := dataBase.GetSenderBlackouts()
+			So(err, ShouldBeNil)
+			So(blackouts, ShouldBeEmpty)
+		})
+
+		Convey("Set, get and remove blackouts", func() {
+			So(dataBase.SetSenderBlackout(slackBlackout), ShouldBeNil)
+			So(dataBase.SetSenderBlackout(mailBlackout), ShouldBeNil)
+
+			blackouts, err := dataBase.GetSenderBlackouts()
+			So(err, ShouldBeNil)
+			So(blackouts, ShouldResemble, []*moira.SenderBlackout{mailBlackout, slackBlackout})
+
+			So(dataBase.RemoveSenderBlackout("mail"), ShouldBeNil)
+
+			blackouts, err = dataBase.GetSenderBlackouts()
+			So(err, ShouldBeNil)
+			So(blackouts, ShouldResemble, []*moira.SenderBlackout{slackBlackout})
+
+			So(dataBase.RemoveSenderBlackout("slack"), ShouldBeNil)
+		})
+
+		Convey("Set blackout replaces existing one", func() {
+			So(dataBase.SetSenderBlackout(slackBlackout), ShouldBeNil)
+			updated := *slackBlackout
+			updated.Mode = moira.SenderBlackoutModeQueue
+			So(dataBase.SetSenderBlackout(&updated), ShouldBeNil)
+
+			blackouts, err := dataBase.GetSenderBlackouts()
+			So(err, ShouldBeNil)
+			So(blackouts, ShouldResemble, []*moira.SenderBlackout{&updated})
+
+			So(dataBase.RemoveSenderBlackout("slack"), ShouldBeNil)
+		})
+
+		Convey("Finished blackouts are not returned and removed", func() {
+			finished := *mailBlackout
+			finished.Until = time.Now().Add(-time.Minute).Unix()
+			So(dataBase.SetSenderBlackout(slackBlackout), ShouldBeNil)
+			So(dataBase.SetSenderBlackout(&finished), ShouldBeNil)
+
+			blackouts, err := dataBase.GetSenderBlackouts()
+			So(err, ShouldBeNil)
+			So(blackouts, ShouldResemble, []*moira.SenderBlackout{slackBlackout})
+
+			client := *dataBase.client
+			exists, err := client.HExists(dataBase.context, senderBlackoutsKey, finished.ContactType).Result()
+			So(err, ShouldBeNil)
+			So(exists, ShouldBeFalse)
+
+			So(dataBase.RemoveSenderBlackout("slack"), ShouldBeNil)
+		})
+
+		Convey("Broken blackout does not affect other ones", func() {
+			So(dataBase.SetSenderBlackout(slackBlackout), ShouldBeNil)
+			client := *dataBase.client
+			So(client.HSet(dataBase.context, senderBlackoutsKey, "mail", "{broken").Err(), ShouldBeNil)
+
+			blackouts, err := dataBase.GetSenderBlackouts()
+			So(err, ShouldBeNil)
+			So(blackouts, ShouldResemble, []*moira.SenderBlackout{slackBlackout})
+
+			So(dataBase.RemoveSenderBlackout("mail"), ShouldBeNil)
+			So(dataBase.RemoveSenderBlackout("slack"), ShouldBeNil)
+		})
+
+		Convey("Remove not existing blackout", func() {
+			So(dataBase.RemoveSenderBlackout("telegram"), ShouldBeNil)
+		})
+	})
+}
+
+func TestSenderBlackoutsErrorConnection(t *testing.T) {
+	logger, _ := logging.GetLogger("dataBase")
+	dataBase := NewTestDatabaseWithIncorrectConfig(logger)
+	dataBase.Flush()
+	defer dataBase.Flush()
+
+	Convey("Should throw error when no connection", t, func() {
+		blackouts, err := dataBase.GetSenderBlackouts()
+		So(blackouts, ShouldBeNil)
+		So(err, ShouldNotBeNil)
+
+		err = dataBase.SetSenderBlackout(&moira.SenderBlackout{ContactType: "slack"})
+		So(err, ShouldNotBeNil)
+
+		err = dataBase.RemoveSenderBlackout("slack")
+		So(err, ShouldNotBeNil)
+	})
+}
@@ -197,6 +197,31 @@ type ContactData struct {
 	Team  string `json:"team"`
 }
 
+// SenderBlackoutMode defines how notifications to the contacts of disabled sender type are handled
+type SenderBlackoutMode string
+
+const (
+	// SenderBlackoutModeFallback skips notifications to the contacts of disabled sender type if
+	// subscription has other contacts to receive them, otherwise postpones them like SenderBlackoutModeQueue
+	SenderBlackoutModeFallback SenderBlackoutMode = "fallback"
+	// SenderBlackoutModeQueue postpones notifications to the contacts of disabled sender type until blackout ends
+	SenderBlackoutModeQueue SenderBlackoutMode = "queue"
+)
+
+// SenderBlackout represents globally disabled sender type
+type SenderBlackout struct {
+	ContactType string             `json:"contact_type" example:"slack"`
+	Mode        SenderBlackoutMode `json:"mode" example:"fallback"`
+	Until       int64              `json:"until,omitempty" example:"1590741878" format:"int64"`
+	CreatedBy   string             `json:"created_by" example:"moira.team"`
+	CreatedAt   int64              `json:"created_at" example:"1590741878" format:"int64"`
+}
+
+// IsActive checks if sender is still disabled at given unix time, blackout without until lasts until it is removed
+func (blackout *SenderBlackout) IsActive(now int64) bool {
+	return blackout.Until == 0 || now < blackout.Until
+}
+
 // SubscriptionData represents user subscription
 type SubscriptionData struct {
 	Contacts          []string     `json:"contacts" example:"acd2db98-1659-4a2f-b227-52d71f6e3ba1"`
@@ -213,6 +238,13 @@ type SubscriptionData struct {
 	TeamID            string       `json:"team_id" example:"324516ed-4924-4154-a62c-eb124234fce"`
 }
 
+// DeletedSubscription represents subscription stored in the recycle bin
+type DeletedSubscription struct {
+	Subscription SubscriptionData `json:"subscription"`
+	DeletedBy    string           `json:"deleted_by" example:"moira.team"`
+	DeletedAt    int64            `json:"deleted_at" example:"1590741878" format:"int64"`
+}
+
 // PlottingData represents plotting settings
 type PlottingData struct {
 	Enabled bool   `json:"enabled" example:"true"`
@@ -385,6 +417,26 @@ type TriggerCheck struct {
 	Highlights map[string]string `json:"highlights"`
 }
 
+// DeletedTrigger represents trigger stored in the recycle bin
+type DeletedTrigger struct {
+	Trigger   Trigger `json:"trigger"`
+	DeletedBy string  `json:"deleted_by" example:"moira.team"`
+	DeletedAt int64   `json:"deleted_at" example:"1590741878" format:"int64"`
+}
+
+// TriggerStateChange represents the last change of trigger state or trigger deletion
+type TriggerStateChange struct {
+	TriggerID string `json:"trigger_id" example:"292516ed-4924-4154-a62c-ebe312431fce"`
+	// New state of trigger, it is empty if trigger was deleted
+	State State `json:"state,omitempty" example:"ERROR"`
+	// True if trigger was deleted
+	Deleted bool `json:"deleted,omitempty" example:"false"`
+	// Unix time of the check, which changed trigger state, or of trigger deletion
+	Timestamp int64 `json:"timestamp" example:"1590741878" format:"int64"`
+	// Number of the change, changes committed later have greater numbers
+	Sequence int64 `json:"sequence" example:"42" format:"int64"`
+}
+
 // SearchOptions represents the options that can be selected when searching triggers
 type SearchOptions struct {
 	Page                  int64
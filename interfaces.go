@@ -19,6 +19,11 @@ type Database interface {
 	GetNotifierState() (string, error)
 	SetNotifierState(string) error
 
+	// Sender blackouts storing
+	GetSenderBlackouts() ([]*SenderBlackout, error)
+	SetSenderBlackout(blackout *SenderBlackout) error
+	RemoveSenderBlackout(contactType string) error
+
 	// Tag storing
 	GetTagNames() ([]string, error)
 	RemoveTag(tagName string) error
@@ -27,10 +32,12 @@ type Database interface {
 
 	// LastCheck storing
 	GetTriggerLastCheck(triggerID string) (CheckData, error)
+	GetTriggersLastCheck(triggerIDs []string) ([]*CheckData, error)
 	SetTriggerLastCheck(triggerID string, checkData *CheckData, triggerSource TriggerSource) error
 	RemoveTriggerLastCheck(triggerID string) error
 	SetTriggerCheckMaintenance(triggerID string, metrics map[string]int64, triggerMaintenance *int64, userLogin string, timeCallMaintenance int64) error
 	CleanUpAbandonedTriggerLastCheck() error
+	GetTriggersStateChanges(from int64) ([]*TriggerStateChange, error)
 
 	// Trigger storing
 	GetAllTriggerIDs() ([]string, error)
@@ -49,6 +56,16 @@ type Database interface {
 	RemovePatternTriggerIDs(pattern string) error
 	GetTriggerIDsStartWith(prefix string) ([]string, error)
 
+	// Recycle bin storing
+	AddTriggerToRecycleBin(deletedTrigger *DeletedTrigger, ttl time.Duration) error
+	GetRecycleBinTrigger(triggerID string) (DeletedTrigger, error)
+	GetRecycleBinTriggers() ([]*DeletedTrigger, error)
+	RemoveTriggerFromRecycleBin(triggerID string) error
+	AddSubscriptionToRecycleBin(deletedSubscription *DeletedSubscription, ttl time.Duration) error
+	GetRecycleBinSubscription(subscriptionID string) (DeletedSubscription, error)
+	GetRecycleBinSubscriptions() ([]*DeletedSubscription, error)
+	RemoveSubscriptionFromRecycleBin(subscriptionID string) error
+
 	// SearchResult AKA pager storing
 	GetTriggersSearchResults(searchResultsID string, page, size int64) ([]*SearchResult, int64, error)
 	SaveTriggersSearchResults(searchResultsID string, searchResults []*SearchResult) error
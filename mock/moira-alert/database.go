@@ -134,6 +134,34 @@ func (mr *MockDatabaseMockRecorder) AddRemoteTriggersToCheck(arg0 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddRemoteTriggersToCheck", reflect.TypeOf((*MockDatabase)(nil).AddRemoteTriggersToCheck), arg0)
 }
 
+// AddSubscriptionToRecycleBin mocks base method.
+func (m *MockDatabase) AddSubscriptionToRecycleBin(arg0 *moira.DeletedSubscription, arg1 time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddSubscriptionToRecycleBin", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddSubscriptionToRecycleBin indicates an expected call of AddSubscriptionToRecycleBin.
+func (mr *MockDatabaseMockRecorder) AddSubscriptionToRecycleBin(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSubscriptionToRecycleBin", reflect.TypeOf((*MockDatabase)(nil).AddSubscriptionToRecycleBin), arg0, arg1)
+}
+
+// AddTriggerToRecycleBin mocks base method.
+func (m *MockDatabase) AddTriggerToRecycleBin(arg0 *moira.DeletedTrigger, arg1 time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTriggerToRecycleBin", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddTriggerToRecycleBin indicates an expected call of AddTriggerToRecycleBin.
+func (mr *MockDatabaseMockRecorder) AddTriggerToRecycleBin(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTriggerToRecycleBin", reflect.TypeOf((*MockDatabase)(nil).AddTriggerToRecycleBin), arg0, arg1)
+}
+
 // CleanUpAbandonedRetentions mocks base method.
 func (m *MockDatabase) CleanUpAbandonedRetentions() error {
 	m.ctrl.T.Helper()
@@ -666,6 +694,66 @@ func (mr *MockDatabaseMockRecorder) GetPrometheusTriggersToCheckCount() *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrometheusTriggersToCheckCount", reflect.TypeOf((*MockDatabase)(nil).GetPrometheusTriggersToCheckCount))
 }
 
+// GetRecycleBinSubscription mocks base method.
+func (m *MockDatabase) GetRecycleBinSubscription(arg0 string) (moira.DeletedSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecycleBinSubscription", arg0)
+	ret0, _ := ret[0].(moira.DeletedSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecycleBinSubscription indicates an expected call of GetRecycleBinSubscription.
+func (mr *MockDatabaseMockRecorder) GetRecycleBinSubscription(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecycleBinSubscription", reflect.TypeOf((*MockDatabase)(nil).GetRecycleBinSubscription), arg0)
+}
+
+// GetRecycleBinSubscriptions mocks base method.
+func (m *MockDatabase) GetRecycleBinSubscriptions() ([]*moira.DeletedSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecycleBinSubscriptions")
+	ret0, _ := ret[0].([]*moira.DeletedSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecycleBinSubscriptions indicates an expected call of GetRecycleBinSubscriptions.
+func (mr *MockDatabaseMockRecorder) GetRecycleBinSubscriptions() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecycleBinSubscriptions", reflect.TypeOf((*MockDatabase)(nil).GetRecycleBinSubscriptions))
+}
+
+// GetRecycleBinTrigger mocks base method.
+func (m *MockDatabase) GetRecycleBinTrigger(arg0 string) (moira.DeletedTrigger, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecycleBinTrigger", arg0)
+	ret0, _ := ret[0].(moira.DeletedTrigger)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecycleBinTrigger indicates an expected call of GetRecycleBinTrigger.
+func (mr *MockDatabaseMockRecorder) GetRecycleBinTrigger(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecycleBinTrigger", reflect.TypeOf((*MockDatabase)(nil).GetRecycleBinTrigger), arg0)
+}
+
+// GetRecycleBinTriggers mocks base method.
+func (m *MockDatabase) GetRecycleBinTriggers() ([]*moira.DeletedTrigger, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecycleBinTriggers")
+	ret0, _ := ret[0].([]*moira.DeletedTrigger)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecycleBinTriggers indicates an expected call of GetRecycleBinTriggers.
+func (mr *MockDatabaseMockRecorder) GetRecycleBinTriggers() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecycleBinTriggers", reflect.TypeOf((*MockDatabase)(nil).GetRecycleBinTriggers))
+}
+
 // GetRemoteChecksUpdatesCount mocks base method.
 func (m *MockDatabase) GetRemoteChecksUpdatesCount() (int64, error) {
 	m.ctrl.T.Helper()
@@ -726,6 +814,21 @@ func (mr *MockDatabaseMockRecorder) GetRemoteTriggersToCheckCount() *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRemoteTriggersToCheckCount", reflect.TypeOf((*MockDatabase)(nil).GetRemoteTriggersToCheckCount))
 }
 
+// GetSenderBlackouts mocks base method.
+func (m *MockDatabase) GetSenderBlackouts() ([]*moira.SenderBlackout, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSenderBlackouts")
+	ret0, _ := ret[0].([]*moira.SenderBlackout)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSenderBlackouts indicates an expected call of GetSenderBlackouts.
+func (mr *MockDatabaseMockRecorder) GetSenderBlackouts() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSenderBlackouts", reflect.TypeOf((*MockDatabase)(nil).GetSenderBlackouts))
+}
+
 // GetSubscription mocks base method.
 func (m *MockDatabase) GetSubscription(arg0 string) (moira.SubscriptionData, error) {
 	m.ctrl.T.Helper()
@@ -966,6 +1069,21 @@ func (mr *MockDatabaseMockRecorder) GetTriggers(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTriggers", reflect.TypeOf((*MockDatabase)(nil).GetTriggers), arg0)
 }
 
+// GetTriggersLastCheck mocks base method.
+func (m *MockDatabase) GetTriggersLastCheck(arg0 []string) ([]*moira.CheckData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTriggersLastCheck", arg0)
+	ret0, _ := ret[0].([]*moira.CheckData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTriggersLastCheck indicates an expected call of GetTriggersLastCheck.
+func (mr *MockDatabaseMockRecorder) GetTriggersLastCheck(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTriggersLastCheck", reflect.TypeOf((*MockDatabase)(nil).GetTriggersLastCheck), arg0)
+}
+
 // GetTriggersSearchResults mocks base method.
 func (m *MockDatabase) GetTriggersSearchResults(arg0 string, arg1, arg2 int64) ([]*moira.SearchResult, int64, error) {
 	m.ctrl.T.Helper()
@@ -982,6 +1100,21 @@ func (mr *MockDatabaseMockRecorder) GetTriggersSearchResults(arg0, arg1, arg2 in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTriggersSearchResults", reflect.TypeOf((*MockDatabase)(nil).GetTriggersSearchResults), arg0, arg1, arg2)
 }
 
+// GetTriggersStateChanges mocks base method.
+func (m *MockDatabase) GetTriggersStateChanges(arg0 int64) ([]*moira.TriggerStateChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTriggersStateChanges", arg0)
+	ret0, _ := ret[0].([]*moira.TriggerStateChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTriggersStateChanges indicates an expected call of GetTriggersStateChanges.
+func (mr *MockDatabaseMockRecorder) GetTriggersStateChanges(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTriggersStateChanges", reflect.TypeOf((*MockDatabase)(nil).GetTriggersStateChanges), arg0)
+}
+
 // GetUnusedTriggerIDs mocks base method.
 func (m *MockDatabase) GetUnusedTriggerIDs() ([]string, error) {
 	m.ctrl.T.Helper()
@@ -1346,6 +1479,20 @@ func (mr *MockDatabaseMockRecorder) RemovePatternsMetrics(arg0 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemovePatternsMetrics", reflect.TypeOf((*MockDatabase)(nil).RemovePatternsMetrics), arg0)
 }
 
+// RemoveSenderBlackout mocks base method.
+func (m *MockDatabase) RemoveSenderBlackout(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveSenderBlackout", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveSenderBlackout indicates an expected call of RemoveSenderBlackout.
+func (mr *MockDatabaseMockRecorder) RemoveSenderBlackout(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveSenderBlackout", reflect.TypeOf((*MockDatabase)(nil).RemoveSenderBlackout), arg0)
+}
+
 // RemoveSubscription mocks base method.
 func (m *MockDatabase) RemoveSubscription(arg0 string) error {
 	m.ctrl.T.Helper()
@@ -1360,6 +1507,20 @@ func (mr *MockDatabaseMockRecorder) RemoveSubscription(arg0 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveSubscription", reflect.TypeOf((*MockDatabase)(nil).RemoveSubscription), arg0)
 }
 
+// RemoveSubscriptionFromRecycleBin mocks base method.
+func (m *MockDatabase) RemoveSubscriptionFromRecycleBin(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveSubscriptionFromRecycleBin", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveSubscriptionFromRecycleBin indicates an expected call of RemoveSubscriptionFromRecycleBin.
+func (mr *MockDatabaseMockRecorder) RemoveSubscriptionFromRecycleBin(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveSubscriptionFromRecycleBin", reflect.TypeOf((*MockDatabase)(nil).RemoveSubscriptionFromRecycleBin), arg0)
+}
+
 // RemoveTag mocks base method.
 func (m *MockDatabase) RemoveTag(arg0 string) error {
 	m.ctrl.T.Helper()
@@ -1388,6 +1549,20 @@ func (mr *MockDatabaseMockRecorder) RemoveTrigger(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveTrigger", reflect.TypeOf((*MockDatabase)(nil).RemoveTrigger), arg0)
 }
 
+// RemoveTriggerFromRecycleBin mocks base method.
+func (m *MockDatabase) RemoveTriggerFromRecycleBin(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveTriggerFromRecycleBin", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveTriggerFromRecycleBin indicates an expected call of RemoveTriggerFromRecycleBin.
+func (mr *MockDatabaseMockRecorder) RemoveTriggerFromRecycleBin(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveTriggerFromRecycleBin", reflect.TypeOf((*MockDatabase)(nil).RemoveTriggerFromRecycleBin), arg0)
+}
+
 // RemoveTriggerLastCheck mocks base method.
 func (m *MockDatabase) RemoveTriggerLastCheck(arg0 string) error {
 	m.ctrl.T.Helper()
@@ -1556,6 +1731,20 @@ func (mr *MockDatabaseMockRecorder) SetNotifierState(arg0 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetNotifierState", reflect.TypeOf((*MockDatabase)(nil).SetNotifierState), arg0)
 }
 
+// SetSenderBlackout mocks base method.
+func (m *MockDatabase) SetSenderBlackout(arg0 *moira.SenderBlackout) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSenderBlackout", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetSenderBlackout indicates an expected call of SetSenderBlackout.
+func (mr *MockDatabaseMockRecorder) SetSenderBlackout(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSenderBlackout", reflect.TypeOf((*MockDatabase)(nil).SetSenderBlackout), arg0)
+}
+
 // SetTriggerCheckLock mocks base method.
 func (m *MockDatabase) SetTriggerCheckLock(arg0 string) (bool, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,143 @@
+package notifications
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/database"
+)
+
+// blackedOutNotificationDelay is the time for which notification to the disabled sender is postponed
+const blackedOutNotificationDelay = time.Minute
+
+// getActiveSenderBlackouts returns active sender blackouts by contact type
+func (worker *FetchNotificationsWorker) getActiveSenderBlackouts(now time.Time) (map[string]*moira.SenderBlackout, error) {
+	blackouts, err := worker.Database.GetSenderBlackouts()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sender blackouts: %s", err.Error())
+	}
+
+	active := make(map[string]*moira.SenderBlackout, len(blackouts))
+	for _, blackout := range blackouts {
+		if blackout.IsActive(now.Unix()) {
+			active[blackout.ContactType] = blackout
+		}
+	}
+	return active, nil
+}
+
+// senderBlackoutsHandler skips or postpones notifications to the disabled senders,
+// it caches subscriptions contacts so it must be used only within one notifications processing iteration
+type senderBlackoutsHandler struct {
+	worker                *FetchNotificationsWorker
+	blackouts             map[string]*moira.SenderBlackout
+	subscriptionsContacts map[string][]*moira.ContactData
+	now                   time.Time
+}
+
+func (worker *FetchNotificationsWorker) newBlackoutsHandler(blackouts map[string]*moira.SenderBlackout, now time.Time) *senderBlackoutsHandler {
+	return &senderBlackoutsHandler{
+		worker:                worker,
+		blackouts:             blackouts,
+		subscriptionsContacts: make(map[string][]*moira.ContactData),
+		now:                   now,
+	}
+}
+
+// handle returns false if notification contact type is not disabled or notification can not be postponed,
+// otherwise handles notification according to the blackout mode
+func (handler *senderBlackoutsHandler) handle(notification *moira.ScheduledNotification) bool {
+	blackout, found := handler.blackouts[notification.Contact.Type]
+	if !found {
+		return false
+	}
+
+	logger := handler.worker.Logger.Clone().
+		String(moira.LogFieldNameContactID, notification.Contact.ID).
+		String(moira.LogFieldNameContactType, notification.Contact.Type).
+		String(moira.LogFieldNameTriggerID, notification.Event.TriggerID)
+
+	if blackout.Mode == moira.SenderBlackoutModeFallback {
+		hasFallback, err := handler.hasFallbackContacts(notification)
+		if err != nil {
+			logger.Warning().
+				Error(err).
+				Msg("Failed to get fallback contacts, postpone notification to disabled sender")
+		} else if hasFallback {
+			logger.Debug().
+				Msg("Skip notification to disabled sender, subscription has other contacts to receive it")
+			return true
+		}
+	}
+
+	timestamp := notification.Timestamp
+	notification.Timestamp = getPostponedTimestamp(blackout, handler.now)
+	if err := handler.worker.Database.AddNotification(notification); err != nil {
+		logger.Error().
+			Error(err).
+			Msg("Failed to postpone notification to disabled sender, send it")
+		notification.Timestamp = timestamp
+		return false
+	}
+
+	logger.Debug().
+		Msg("Notification to disabled sender postponed")
+	return true
+}
+
+// getPostponedTimestamp returns the time for which notification to the disabled sender is postponed,
+// notification is not postponed beyond the blackout end
+func getPostponedTimestamp(blackout *moira.SenderBlackout, now time.Time) int64 {
+	timestamp := now.Add(blackedOutNotificationDelay).Unix()
+	if blackout.Until != 0 && blackout.Until < timestamp {
+		return blackout.Until
+	}
+	return timestamp
+}
+
+// hasFallbackContacts checks if notification subscription has other contacts which senders are not disabled,
+// these contacts receive the same notifications, so notification to the disabled sender can be skipped
+func (handler *senderBlackoutsHandler) hasFallbackContacts(notification *moira.ScheduledNotification) (bool, error) {
+	if notification.Event.SubscriptionID == nil {
+		return false, nil
+	}
+
+	contacts, err := handler.getSubscriptionContacts(*notification.Event.SubscriptionID)
+	if err != nil {
+		return false, err
+	}
+
+	for _, contact := range contacts {
+		if contact == nil || contact.ID == notification.Contact.ID {
+			continue
+		}
+		if _, disabled := handler.blackouts[contact.Type]; !disabled {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (handler *senderBlackoutsHandler) getSubscriptionContacts(subscriptionID string) ([]*moira.ContactData, error) {
+	if contacts, found := handler.subscriptionsContacts[subscriptionID]; found {
+		return contacts, nil
+	}
+
+	subscription, err := handler.worker.Database.GetSubscription(subscriptionID)
+	if err != nil && !errors.Is(err, database.ErrNil) {
+		return nil, fmt.Errorf("failed to get subscription %s: %s", subscriptionID, err.Error())
+	}
+
+	contacts := make([]*moira.ContactData, 0)
+	if len(subscription.Contacts) > 0 {
+		contacts, err = handler.worker.Database.GetContacts(subscription.Contacts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get subscription %s contacts: %s", subscriptionID, err.Error())
+		}
+	}
+
+	handler.subscriptionsContacts[subscriptionID] = contacts
+	return contacts, nil
+}
@@ -0,0 +1,179 @@
+package notifications
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/database"
+	logging "github.com/moira-alert/moira/logging/zerolog_adapter"
+	mock_moira_alert "github.com/moira-alert/moira/mock/moira-alert"
+	mock_notifier "github.com/moira-alert/moira/mock/notifier"
+	notifier2 "github.com/moira-alert/moira/notifier"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestProcessScheduledEventWithSenderBlackouts(t *testing.T) {
+	subID := "subscriptionID-00000000000001"
+	slackContact := moira.ContactData{ID: "ContactID-000000000000002", Type: "slack", Value: "#channel"}
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	dataBase := mock_moira_alert.NewMockDatabase(mockCtrl)
+	notifier := mock_notifier.NewMockNotifier(mockCtrl)
+	logger, _ := logging.GetLogger("Notification")
+	worker := &FetchNotificationsWorker{
+		Database: dataBase,
+		Logger:   logger,
+		Notifier: notifier,
+		Metrics:  notifierMetrics,
+	}
+
+	newNotification := func() *moira.ScheduledNotification {
+		return &moira.ScheduledNotification{
+			Event: moira.NotificationEvent{
+				SubscriptionID: &subID,
+				State:          moira.StateERROR,
+				TriggerID:      "triggerID-00000000000001",
+			},
+			Contact:   slackContact,
+			Timestamp: 1441188915,
+		}
+	}
+
+	expectFetch := func(blackouts []*moira.SenderBlackout, notification *moira.ScheduledNotification) {
+		dataBase.EXPECT().GetNotifierState().Return(moira.SelfStateOK, nil)
+		dataBase.EXPECT().GetSenderBlackouts().Return(blackouts, nil)
+		notifier.EXPECT().GetReadBatchSize().Return(notifier2.NotificationsLimitUnlimited)
+		dataBase.EXPECT().FetchNotifications(gomock.Any(), notifier2.NotificationsLimitUnlimited).Return([]*moira.ScheduledNotification{notification}, nil)
+	}
+
+	expectPostpone := func(notification *moira.ScheduledNotification) {
+		dataBase.EXPECT().AddNotification(notification).Do(func(postponed *moira.ScheduledNotification) {
+			So(postponed.Timestamp, ShouldBeGreaterThanOrEqualTo, time.Now().Add(blackedOutNotificationDelay-time.Second).Unix())
+			So(postponed.SendFail, ShouldEqual, 0)
+		}).Return(nil)
+	}
+
+	queueBlackout := &moira.SenderBlackout{ContactType: "slack", Mode: moira.SenderBlackoutModeQueue}
+	fallbackBlackout := &moira.SenderBlackout{ContactType: "slack", Mode: moira.SenderBlackoutModeFallback}
+
+	Convey("Finished blackout, should send package", t, func() {
+		notification := newNotification()
+		finished := &moira.SenderBlackout{ContactType: "slack", Mode: moira.SenderBlackoutModeQueue, Until: time.Now().Add(-time.Minute).Unix()}
+		expectFetch([]*moira.SenderBlackout{finished}, notification)
+		dataBase.EXPECT().PushContactNotificationToHistory(notification).Return(nil)
+		notifier.EXPECT().Send(gomock.Any(), gomock.Any())
+
+		err := worker.processScheduledNotifications()
+		So(err, ShouldBeNil)
+	})
+
+	Convey("Queue mode, should postpone notification", t, func() {
+		notification := newNotification()
+		expectFetch([]*moira.SenderBlackout{queueBlackout}, notification)
+		expectPostpone(notification)
+
+		err := worker.processScheduledNotifications()
+		So(err, ShouldBeNil)
+	})
+
+	Convey("Queue mode, blackout ends soon, should postpone notification until blackout end", t, func() {
+		notification := newNotification()
+		until := time.Now().Add(blackedOutNotificationDelay / 2).Unix()
+		endingBlackout := &moira.SenderBlackout{ContactType: "slack", Mode: moira.SenderBlackoutModeQueue, Until: until}
+		expectFetch([]*moira.SenderBlackout{endingBlackout}, notification)
+		dataBase.EXPECT().AddNotification(notification).Do(func(postponed *moira.ScheduledNotification) {
+			So(postponed.Timestamp, ShouldEqual, until)
+		}).Return(nil)
+
+		err := worker.processScheduledNotifications()
+		So(err, ShouldBeNil)
+	})
+
+	Convey("Queue mode, failed to postpone notification, should send package", t, func() {
+		notification := newNotification()
+		expectFetch([]*moira.SenderBlackout{queueBlackout}, notification)
+		dataBase.EXPECT().AddNotification(notification).Return(fmt.Errorf("some error"))
+		dataBase.EXPECT().PushContactNotificationToHistory(notification).Return(nil)
+		notifier.EXPECT().Send(gomock.Any(), gomock.Any())
+
+		err := worker.processScheduledNotifications()
+		So(err, ShouldBeNil)
+		So(notification.Timestamp, ShouldEqual, 1441188915)
+	})
+
+	Convey("Fallback mode", t, func() {
+		Convey("Subscription has other contact, should skip notification", func() {
+			notification := newNotification()
+			expectFetch([]*moira.SenderBlackout{fallbackBlackout}, notification)
+			dataBase.EXPECT().GetSubscription(subID).Return(moira.SubscriptionData{ID: subID, Contacts: []string{slackContact.ID, contact1.ID}}, nil)
+			dataBase.EXPECT().GetContacts([]string{slackContact.ID, contact1.ID}).Return([]*moira.ContactData{&slackContact, &contact1}, nil)
+
+			err := worker.processScheduledNotifications()
+			So(err, ShouldBeNil)
+		})
+
+		Convey("Other subscription contacts are disabled, should postpone notification", func() {
+			notification := newNotification()
+			mailBlackout := &moira.SenderBlackout{ContactType: contact1.Type, Mode: moira.SenderBlackoutModeQueue}
+			expectFetch([]*moira.SenderBlackout{fallbackBlackout, mailBlackout}, notification)
+			dataBase.EXPECT().GetSubscription(subID).Return(moira.SubscriptionData{ID: subID, Contacts: []string{slackContact.ID, contact1.ID}}, nil)
+			dataBase.EXPECT().GetContacts([]string{slackContact.ID, contact1.ID}).Return([]*moira.ContactData{&slackContact, &contact1}, nil)
+			expectPostpone(notification)
+
+			err := worker.processScheduledNotifications()
+			So(err, ShouldBeNil)
+		})
+
+		Convey("Subscription has been removed, should postpone notification", func() {
+			notification := newNotification()
+			expectFetch([]*moira.SenderBlackout{fallbackBlackout}, notification)
+			dataBase.EXPECT().GetSubscription(subID).Return(moira.SubscriptionData{}, database.ErrNil)
+			expectPostpone(notification)
+
+			err := worker.processScheduledNotifications()
+			So(err, ShouldBeNil)
+		})
+
+		Convey("Failed to get subscription, should postpone notification", func() {
+			notification := newNotification()
+			expectFetch([]*moira.SenderBlackout{fallbackBlackout}, notification)
+			dataBase.EXPECT().GetSubscription(subID).Return(moira.SubscriptionData{}, fmt.Errorf("some error"))
+			expectPostpone(notification)
+
+			err := worker.processScheduledNotifications()
+			So(err, ShouldBeNil)
+		})
+
+		Convey("Subscription contacts are fetched once per iteration", func() {
+			notification1 := newNotification()
+			notification2 := newNotification()
+			notification2.Event.TriggerID = "triggerID-00000000000002"
+			dataBase.EXPECT().GetNotifierState().Return(moira.SelfStateOK, nil)
+			dataBase.EXPECT().GetSenderBlackouts().Return([]*moira.SenderBlackout{fallbackBlackout}, nil)
+			notifier.EXPECT().GetReadBatchSize().Return(notifier2.NotificationsLimitUnlimited)
+			dataBase.EXPECT().FetchNotifications(gomock.Any(), notifier2.NotificationsLimitUnlimited).Return([]*moira.ScheduledNotification{notification1, notification2}, nil)
+			dataBase.EXPECT().GetSubscription(subID).Return(moira.SubscriptionData{ID: subID, Contacts: []string{slackContact.ID, contact1.ID}}, nil)
+			dataBase.EXPECT().GetContacts([]string{slackContact.ID, contact1.ID}).Return([]*moira.ContactData{&slackContact, &contact1}, nil)
+
+			err := worker.processScheduledNotifications()
+			So(err, ShouldBeNil)
+		})
+	})
+
+	Convey("Failed to get sender blackouts, should send package", t, func() {
+		notification := newNotification()
+		dataBase.EXPECT().GetNotifierState().Return(moira.SelfStateOK, nil)
+		dataBase.EXPECT().GetSenderBlackouts().Return(nil, fmt.Errorf("some error"))
+		notifier.EXPECT().GetReadBatchSize().Return(notifier2.NotificationsLimitUnlimited)
+		dataBase.EXPECT().FetchNotifications(gomock.Any(), notifier2.NotificationsLimitUnlimited).Return([]*moira.ScheduledNotification{notification}, nil)
+		dataBase.EXPECT().PushContactNotificationToHistory(notification).Return(nil)
+		notifier.EXPECT().Send(gomock.Any(), gomock.Any())
+
+		err := worker.processScheduledNotifications()
+		So(err, ShouldBeNil)
+	})
+}
@@ -79,6 +79,15 @@ func (worker *FetchNotificationsWorker) processScheduledNotifications() error {
 		return notifierInBadStateError(fmt.Sprintf("notifier in a bad state: %v", state))
 	}
 
+	// notifications must not stop because of broken blackouts, so the senders are considered enabled
+	blackouts, err := worker.getActiveSenderBlackouts(time.Now())
+	if err != nil {
+		worker.Logger.Error().
+			Error(err).
+			Msg("Failed to get sender blackouts, send notifications to all senders")
+		blackouts = make(map[string]*moira.SenderBlackout)
+	}
+
 	fetchNotificationsStartTime := time.Now()
 	notifications, err := worker.Database.FetchNotifications(time.Now().Unix(), worker.Notifier.GetReadBatchSize())
 	if err != nil {
@@ -86,8 +95,13 @@ func (worker *FetchNotificationsWorker) processScheduledNotifications() error {
 	}
 	worker.updateFetchNotificationsMetric(fetchNotificationsStartTime)
 
+	blackoutsHandler := worker.newBlackoutsHandler(blackouts, time.Now())
 	notificationPackages := make(map[string]*notifier.NotificationPackage)
 	for _, notification := range notifications {
+		if blackoutsHandler.handle(notification) {
+			continue
+		}
+
 		packageKey := fmt.Sprintf("%s:%s:%s", notification.Contact.Type, notification.Contact.Value, notification.Event.TriggerID)
 		p, found := notificationPackages[packageKey]
 		if !found {
@@ -98,6 +98,7 @@ func TestProcessScheduledEvent(t *testing.T) {
 		notifier.EXPECT().Send(&pkg2, gomock.Any())
 		notifier.EXPECT().GetReadBatchSize().Return(notifier2.NotificationsLimitUnlimited)
 		dataBase.EXPECT().GetNotifierState().Return(moira.SelfStateOK, nil)
+		dataBase.EXPECT().GetSenderBlackouts().Return(make([]*moira.SenderBlackout, 0), nil)
 		err := worker.processScheduledNotifications()
 		So(err, ShouldBeEmpty)
 	})
@@ -124,6 +125,7 @@ func TestProcessScheduledEvent(t *testing.T) {
 		dataBase.EXPECT().PushContactNotificationToHistory(&notification3).Return(nil).AnyTimes()
 		notifier.EXPECT().Send(&pkg, gomock.Any())
 		dataBase.EXPECT().GetNotifierState().Return(moira.SelfStateOK, nil)
+		dataBase.EXPECT().GetSenderBlackouts().Return(make([]*moira.SenderBlackout, 0), nil)
 		notifier.EXPECT().GetReadBatchSize().Return(notifier2.NotificationsLimitUnlimited)
 		err := worker.processScheduledNotifications()
 		So(err, ShouldBeEmpty)
@@ -173,6 +175,7 @@ func TestGoRoutine(t *testing.T) {
 	notifier.EXPECT().StopSenders()
 	notifier.EXPECT().GetReadBatchSize().Return(notifier2.NotificationsLimitUnlimited)
 	dataBase.EXPECT().GetNotifierState().Return(moira.SelfStateOK, nil)
+	dataBase.EXPECT().GetSenderBlackouts().Return(make([]*moira.SenderBlackout, 0), nil)
 
 	worker.Start()
 	waitTestEnd(shutdown, worker)